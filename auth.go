@@ -1,6 +1,10 @@
 package rlapi
 
-import "fmt"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
 
 type AuthPlayerRequest struct {
 	Platform            string `json:"Platform"`
@@ -27,10 +31,17 @@ type AuthPlayerResponse struct {
 	PerConURLv2         string   `json:"PerConURLv2"`
 	PsyToken            string   `json:"PsyToken"`
 	CountryRestrictions []string `json:"CountryRestrictions"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // AuthPlayer authenticates with PsyNet via EGS and returns a WebSocket connection.
 func (p *PsyNet) AuthPlayer(authToken string, accountID string, accountName string) (*PsyNetRPC, error) {
+	return p.AuthPlayerContext(context.Background(), authToken, accountID, accountName)
+}
+
+// AuthPlayerContext is like AuthPlayer but binds the HTTP request and WebSocket dial to ctx.
+func (p *PsyNet) AuthPlayerContext(ctx context.Context, authToken string, accountID string, accountName string) (*PsyNetRPC, error) {
 	localPlayerId := NewPlayerID(PlatformEpic, accountID)
 	req := &AuthPlayerRequest{
 		Platform:            string(PlatformEpic),
@@ -48,25 +59,16 @@ func (p *PsyNet) AuthPlayer(authToken string, accountID string, accountName stri
 		EpicAccountID:       accountID,
 	}
 
-	var res AuthPlayerResponse
-	err := p.postJSON([]string{"Auth", "AuthPlayer", "v2"}, req, &res)
-	if err != nil {
-		return nil, fmt.Errorf("failed to authenticate player: %w", err)
-	}
-
-	rpc, err := p.establishSocket(res.PerConURLv2, localPlayerId, res.PsyToken, res.SessionID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to establish websocket: %w", err)
-	}
-
-	go rpc.readMessages()
-	rpc.schedulePing()
-
-	return rpc, nil
+	return p.authPlayer(ctx, req, localPlayerId)
 }
 
 // AuthPlayerSteam authenticates with PsyNet via Steam session ticket and returns a WebSocket connection.
 func (p *PsyNet) AuthPlayerSteam(authToken string, epicAccountID string, steamAccountID string, accountName string) (*PsyNetRPC, error) {
+	return p.AuthPlayerSteamContext(context.Background(), authToken, epicAccountID, steamAccountID, accountName)
+}
+
+// AuthPlayerSteamContext is like AuthPlayerSteam but binds the HTTP request and WebSocket dial to ctx.
+func (p *PsyNet) AuthPlayerSteamContext(ctx context.Context, authToken string, epicAccountID string, steamAccountID string, accountName string) (*PsyNetRPC, error) {
 	localPlayerId := NewPlayerID(PlatformSteam, steamAccountID)
 	req := &AuthPlayerRequest{
 		Platform:            string(PlatformSteam),
@@ -83,16 +85,34 @@ func (p *PsyNet) AuthPlayerSteam(authToken string, epicAccountID string, steamAc
 		EpicAccountID:       epicAccountID,
 	}
 
+	return p.authPlayer(ctx, req, localPlayerId)
+}
+
+func (p *PsyNet) authPlayer(ctx context.Context, req *AuthPlayerRequest, localPlayerID PlayerID) (*PsyNetRPC, error) {
 	var res AuthPlayerResponse
-	err := p.postJSON([]string{"Auth", "AuthPlayer", "v2"}, req, &res)
+	err := p.postJSON(ctx, []string{"Auth", "AuthPlayer", "v2"}, req, &res)
 	if err != nil {
 		return nil, fmt.Errorf("failed to authenticate player: %w", err)
 	}
 
-	rpc, err := p.establishSocket(res.PerConURLv2, localPlayerId, res.PsyToken, res.SessionID)
+	mode := p.transportMode
+	if mode == TransportAuto {
+		mode = TransportWebSocket
+		if !res.UseWebSocket {
+			mode = TransportHTTP
+		}
+	}
+
+	if mode == TransportHTTP {
+		p.logger.Debug("using http transport")
+		return p.newHTTPRPC(localPlayerID, res.PsyToken, res.SessionID), nil
+	}
+
+	rpc, err := p.establishSocket(ctx, p.socketURL(res.PerConURLv2), localPlayerID, res.PsyToken, res.SessionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to establish websocket: %w", err)
 	}
+	rpc.authReq = req
 
 	go rpc.readMessages()
 	rpc.schedulePing()
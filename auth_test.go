@@ -0,0 +1,68 @@
+package rlapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPsyNet_AuthPlayerContextCancelsRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := NewPsyNet(WithBaseURL(server.URL)).AuthPlayerContext(ctx, "token", "account", "name")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("AuthPlayerContext() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("AuthPlayerContext() returned after %v, want it bound to the context", elapsed)
+	}
+}
+
+func TestPsyNet_AuthPlayerContextCancelsDial(t *testing.T) {
+	// accepts connections but never answers the WebSocket handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Result":{"UseWebSocket":true,"PsyToken":"token","SessionID":"session"}}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	psyNet := NewPsyNet(WithBaseURL(server.URL), WithWebSocketURL("ws://"+listener.Addr().String()))
+	start := time.Now()
+	_, err = psyNet.AuthPlayerContext(ctx, "token", "account", "name")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("AuthPlayerContext() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("AuthPlayerContext() returned after %v, want the dial bound to the context", elapsed)
+	}
+}
@@ -0,0 +1,196 @@
+package rlapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+const (
+	defaultBatchChunkSize   = 50
+	defaultBatchConcurrency = 4
+)
+
+// BatchOptions configures the batch variants of player lookups, zero values fall back to defaults.
+type BatchOptions struct {
+	// ChunkSize is the number of players sent per request. Defaults to 50.
+	ChunkSize int
+	// Concurrency is the number of requests in flight at once. Defaults to 4.
+	Concurrency int
+}
+
+// ChunkError is the failure of one request of a batch call.
+type ChunkError struct {
+	PlayerIDs []PlayerID
+	Err       error
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("chunk of %d players failed: %v", len(e.PlayerIDs), e.Err)
+}
+
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError is returned by batch calls alongside the merged results of the chunks that succeeded.
+// errors.Is and errors.As match against every chunk's error.
+type BatchError struct {
+	Chunks []*ChunkError
+	// Total is the number of chunks the batch was split into.
+	Total int
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d of %d chunks failed, first: %v", len(e.Chunks), e.Total, e.Chunks[0].Err)
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Chunks))
+	for i, chunk := range e.Chunks {
+		errs[i] = chunk
+	}
+	return errs
+}
+
+// FailedPlayerIDs returns the players of every failed chunk, e.g. to retry them.
+func (e *BatchError) FailedPlayerIDs() []PlayerID {
+	var ids []PlayerID
+	for _, chunk := range e.Chunks {
+		ids = append(ids, chunk.PlayerIDs...)
+	}
+	return ids
+}
+
+// runBatch calls fn for every chunk of playerIDs with bounded concurrency. Results are returned in chunk order
+// with ok false for chunks that failed, the error is a *BatchError if any did.
+func runBatch[T any](ctx context.Context, playerIDs []PlayerID, opts BatchOptions, fn func(ctx context.Context, chunk []PlayerID) (T, error)) ([]T, []bool, error) {
+	size := opts.ChunkSize
+	if size <= 0 {
+		size = defaultBatchChunkSize
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	var chunks [][]PlayerID
+	for start := 0; start < len(playerIDs); start += size {
+		chunks = append(chunks, playerIDs[start:min(start+size, len(playerIDs))])
+	}
+
+	results := make([]T, len(chunks))
+	ok := make([]bool, len(chunks))
+	errs := make([]error, len(chunks))
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = fn(ctx, chunk)
+			ok[i] = errs[i] == nil
+		}()
+	}
+	wg.Wait()
+
+	batchErr := &BatchError{Total: len(chunks)}
+	for i, err := range errs {
+		if err != nil {
+			batchErr.Chunks = append(batchErr.Chunks, &ChunkError{PlayerIDs: chunks[i], Err: err})
+		}
+	}
+	if len(batchErr.Chunks) > 0 {
+		return results, ok, batchErr
+	}
+	return results, ok, nil
+}
+
+// concatBatch merges the list results of a batch in chunk order, failed chunks have none.
+func concatBatch[T any](results [][]T, err error) ([]T, error) {
+	var merged []T
+	for _, result := range results {
+		merged = append(merged, result...)
+	}
+	return merged, err
+}
+
+// GetProfilesBatch is GetProfiles for any number of players. On a *BatchError the profiles of the chunks that succeeded are still returned.
+func (p *PsyNetRPC) GetProfilesBatch(ctx context.Context, playerIDs []PlayerID, opts BatchOptions) ([]PlayerData, error) {
+	results, _, err := runBatch(ctx, playerIDs, opts, p.GetProfiles)
+	return concatBatch(results, err)
+}
+
+// GetPlayersSkillsBatch is GetPlayersSkills for any number of players, see GetProfilesBatch for partial results.
+func (p *PsyNetRPC) GetPlayersSkillsBatch(ctx context.Context, playerIDs []PlayerID, opts BatchOptions) ([]PlayerWithSkills, error) {
+	results, _, err := runBatch(ctx, playerIDs, opts, p.GetPlayersSkills)
+	return concatBatch(results, err)
+}
+
+// GetBanStatusBatch is GetBanStatus for any number of players, see GetProfilesBatch for partial results.
+func (p *PsyNetRPC) GetBanStatusBatch(ctx context.Context, playerIDs []PlayerID, opts BatchOptions) ([]json.RawMessage, error) {
+	results, _, err := runBatch(ctx, playerIDs, opts, p.GetBanStatus)
+	return concatBatch(results, err)
+}
+
+// CanShowAvatarBatch is CanShowAvatar for any number of players, see GetProfilesBatch for partial results.
+func (p *PsyNetRPC) CanShowAvatarBatch(ctx context.Context, playerIDs []PlayerID, opts BatchOptions) (*CanShowAvatarResponse, error) {
+	results, ok, err := runBatch(ctx, playerIDs, opts, p.CanShowAvatar)
+	return mergeCanShowAvatar(results, ok), err
+}
+
+func mergeCanShowAvatar(results []*CanShowAvatarResponse, ok []bool) *CanShowAvatarResponse {
+	merged := &CanShowAvatarResponse{}
+	for i, result := range results {
+		if !ok[i] {
+			continue
+		}
+		merged.AllowedPlayerIDs = append(merged.AllowedPlayerIDs, result.AllowedPlayerIDs...)
+		merged.HiddenPlayerIDs = append(merged.HiddenPlayerIDs, result.HiddenPlayerIDs...)
+	}
+	return merged
+}
+
+// GetSkillLeaderboardRankForUsersBatch is GetSkillLeaderboardRankForUsers for any number of players, see GetProfilesBatch for partial results.
+func (p *PsyNetRPC) GetSkillLeaderboardRankForUsersBatch(ctx context.Context, playlist PlaylistID, playerIDs []PlayerID, opts BatchOptions) (*GetSkillLeaderboardRankForUsersResponse, error) {
+	results, ok, err := runBatch(ctx, playerIDs, opts, func(ctx context.Context, chunk []PlayerID) (*GetSkillLeaderboardRankForUsersResponse, error) {
+		return p.GetSkillLeaderboardRankForUsers(ctx, playlist, chunk)
+	})
+
+	merged := &GetSkillLeaderboardRankForUsersResponse{}
+	for i, result := range results {
+		if !ok[i] {
+			continue
+		}
+		merged.LeaderboardID = result.LeaderboardID
+		merged.Players = append(merged.Players, result.Players...)
+	}
+	return merged, err
+}
+
+// GetStatLeaderboardRankForUsersBatch is GetStatLeaderboardRankForUsers for any number of players, see GetProfilesBatch for partial results.
+func (p *PsyNetRPC) GetStatLeaderboardRankForUsersBatch(ctx context.Context, statName string, playerIDs []PlayerID, opts BatchOptions) (*GetStatLeaderboardRankForUsersResponse, error) {
+	results, ok, err := runBatch(ctx, playerIDs, opts, func(ctx context.Context, chunk []PlayerID) (*GetStatLeaderboardRankForUsersResponse, error) {
+		return p.GetStatLeaderboardRankForUsers(ctx, statName, chunk)
+	})
+
+	merged := &GetStatLeaderboardRankForUsersResponse{}
+	for i, result := range results {
+		if !ok[i] {
+			continue
+		}
+		merged.LeaderboardID = result.LeaderboardID
+		merged.Players = append(merged.Players, result.Players...)
+	}
+	return merged, err
+}
@@ -0,0 +1,100 @@
+package rlapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPsyNetRPC_GetProfilesBatch(t *testing.T) {
+	rpc := newPsyNetRPC(nil, "test-player", NewPsyNet())
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	rpc.Use(func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(5 * time.Millisecond)
+
+		request := info.Request.(GetProfileRequest)
+		if len(request.PlayerIDs) > 3 {
+			return &PsyNetError{Type: "InvalidParameters", Message: "too many players"}
+		}
+		response := result.(*GetProfileResponse)
+		for _, id := range request.PlayerIDs {
+			if id == "Epic|bad|0" {
+				return &PsyNetError{Type: "ServiceUnavailable"}
+			}
+			response.PlayerData = append(response.PlayerData, PlayerData{PlayerID: string(id)})
+		}
+		return nil
+	})
+
+	var ids []PlayerID
+	for i := range 10 {
+		ids = append(ids, PlayerID(fmt.Sprintf("Epic|%d|0", i)))
+	}
+	ids[4] = "Epic|bad|0"
+
+	profiles, err := rpc.GetProfilesBatch(context.Background(), ids, BatchOptions{ChunkSize: 3, Concurrency: 2})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("err = %v, want BatchError", err)
+	}
+	if batchErr.Total != 4 || len(batchErr.Chunks) != 1 {
+		t.Fatalf("BatchError = %+v, want 1 of 4 chunks failed", batchErr)
+	}
+	if failed := batchErr.FailedPlayerIDs(); len(failed) != 3 || failed[0] != "Epic|3|0" {
+		t.Errorf("FailedPlayerIDs() = %v", failed)
+	}
+	var psyErr *PsyNetError
+	if !errors.As(err, &psyErr) || psyErr.Type != "ServiceUnavailable" {
+		t.Errorf("Expected the chunk error to unwrap to ServiceUnavailable, got %v", err)
+	}
+
+	// partial results keep the input order
+	want := []string{"Epic|0|0", "Epic|1|0", "Epic|2|0", "Epic|6|0", "Epic|7|0", "Epic|8|0", "Epic|9|0"}
+	if len(profiles) != len(want) {
+		t.Fatalf("profiles = %v, want %d", profiles, len(want))
+	}
+	for i, profile := range profiles {
+		if profile.PlayerID != want[i] {
+			t.Errorf("profiles[%d] = %s, want %s", i, profile.PlayerID, want[i])
+		}
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("max in flight = %d, want at most 2", maxInFlight)
+	}
+}
+
+func TestPsyNetRPC_CanShowAvatarBatch(t *testing.T) {
+	rpc := newPsyNetRPC(nil, "test-player", NewPsyNet())
+	rpc.Use(func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+		request := info.Request.(CanShowAvatarRequest)
+		response := result.(*CanShowAvatarResponse)
+		response.AllowedPlayerIDs = request.PlayerIDs[:1]
+		response.HiddenPlayerIDs = request.PlayerIDs[1:]
+		return nil
+	})
+
+	ids := []PlayerID{"Epic|1|0", "Epic|2|0", "Epic|3|0", "Epic|4|0", "Epic|5|0"}
+	merged, err := rpc.CanShowAvatarBatch(context.Background(), ids, BatchOptions{ChunkSize: 2})
+	if err != nil {
+		t.Fatalf("CanShowAvatarBatch() error = %v", err)
+	}
+	if len(merged.AllowedPlayerIDs) != 3 || len(merged.HiddenPlayerIDs) != 2 {
+		t.Errorf("merged = %+v", merged)
+	}
+}
@@ -0,0 +1,280 @@
+package rlapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultCacheTTL     = 5 * time.Minute
+	memoryStoreSweepGap = time.Minute
+)
+
+// DefaultCacheTTLs returns the TTLs of services whose data rarely changes, keyed by service name without version.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"Playlists/GetActivePlaylists":   defaultCacheTTL,
+		"Products/GetContainerDropTable": defaultCacheTTL,
+		"Shops/GetStandardShops":         defaultCacheTTL,
+		"Shops/GetShopCatalogue":         defaultCacheTTL,
+		"Regions/GetSubRegions":          defaultCacheTTL,
+		"Drop/GetTradeInFilters":         defaultCacheTTL,
+		"Training/BrowseTrainingData":    defaultCacheTTL,
+	}
+}
+
+// CacheStore holds encoded results for a Cache, implementations must be safe for concurrent use.
+type CacheStore interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+	Delete(key string)
+}
+
+// CacheOptions configures a cache, zero values fall back to defaults.
+type CacheOptions struct {
+	// TTLs maps service names without version, e.g. "Shops/GetStandardShops", to how long their results are kept.
+	// Services not listed are never cached. Defaults to DefaultCacheTTLs.
+	TTLs map[string]time.Duration
+	// Store holds the cached results. Defaults to an in-memory store.
+	Store CacheStore
+}
+
+// CacheStats counts how calls through a cache were answered.
+type CacheStats struct {
+	Hits uint64
+	// Misses are calls that went to the server.
+	Misses uint64
+	// Coalesced are calls that shared the result of an identical call in flight.
+	Coalesced uint64
+}
+
+// Cache answers repeated calls to slowly changing services from a TTL cache keyed by service and request body.
+// Concurrent identical calls are coalesced into one request, see Cache.Interceptor.
+type Cache struct {
+	ttls  map[string]time.Duration
+	store CacheStore
+
+	mu          sync.Mutex
+	flights     map[string]*cacheFlight
+	generations map[string]uint64
+	generation  uint64
+
+	hits, misses, coalesced atomic.Uint64
+}
+
+// cacheFlight is a call in flight that identical calls wait on.
+type cacheFlight struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// NewCache creates a cache, add it to a client with PsyNet.Use or PsyNetRPC.Use.
+func NewCache(opts CacheOptions) *Cache {
+	if opts.TTLs == nil {
+		opts.TTLs = DefaultCacheTTLs()
+	}
+	if opts.Store == nil {
+		opts.Store = NewMemoryStore()
+	}
+	return &Cache{
+		ttls:        opts.TTLs,
+		store:       opts.Store,
+		flights:     make(map[string]*cacheFlight),
+		generations: make(map[string]uint64),
+	}
+}
+
+// Interceptor answers calls to services with a TTL from the cache. It must be added before interceptors
+// that should only see calls reaching the server, e.g. a Recorder. Errors are never cached.
+func (c *Cache) Interceptor() Interceptor {
+	return func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+		ttl, ok := c.ttls[serviceBaseName(info.Service)]
+		if !ok || ttl <= 0 {
+			return next(ctx, info, result)
+		}
+
+		key, err := c.key(info.Service, info.Request)
+		if err != nil {
+			return next(ctx, info, result)
+		}
+
+		for {
+			if data, ok := c.store.Get(key); ok {
+				c.hits.Add(1)
+				return decodeCached(data, result)
+			}
+
+			c.mu.Lock()
+			flight, shared := c.flights[key]
+			if !shared {
+				flight = &cacheFlight{done: make(chan struct{})}
+				c.flights[key] = flight
+			}
+			c.mu.Unlock()
+
+			if !shared {
+				c.misses.Add(1)
+				return c.fetch(ctx, key, ttl, flight, info, result, next)
+			}
+
+			select {
+			case <-flight.done:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			// the leader's own cancellation says nothing about this call, and a result
+			// that couldn't be cached must be fetched again, so both try again
+			if errors.Is(flight.err, context.Canceled) || errors.Is(flight.err, context.DeadlineExceeded) ||
+				(flight.err == nil && flight.data == nil) {
+				continue
+			}
+			if flight.err != nil {
+				return flight.err
+			}
+			c.coalesced.Add(1)
+			return decodeCached(flight.data, result)
+		}
+	}
+}
+
+// fetch makes the call for every caller waiting on flight and caches its result.
+func (c *Cache) fetch(ctx context.Context, key string, ttl time.Duration, flight *cacheFlight, info *CallInfo, result interface{}, next Invoker) error {
+	defer func() {
+		c.mu.Lock()
+		delete(c.flights, key)
+		c.mu.Unlock()
+		close(flight.done)
+	}()
+
+	if flight.err = next(ctx, info, result); flight.err != nil {
+		return flight.err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		// the caller has its result, only the cache misses out
+		return nil
+	}
+	flight.data = data
+	c.store.Set(key, data, ttl)
+	return nil
+}
+
+func decodeCached(data []byte, result interface{}) error {
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("failed to unmarshal cached result: %w", err)
+	}
+	return nil
+}
+
+// key identifies a call by service, invalidation generation and a hash of the request body.
+func (c *Cache) key(service string, request interface{}) (string, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+
+	c.mu.Lock()
+	generation := c.generation + c.generations[serviceBaseName(service)]
+	c.mu.Unlock()
+
+	return service + "#" + strconv.FormatUint(generation, 10) + "#" + hex.EncodeToString(sum[:]), nil
+}
+
+// Invalidate drops every cached result of a service, with or without version, e.g. "Shops/GetStandardShops".
+// Entries are orphaned rather than deleted, so stores without key listing expire them by TTL.
+func (c *Cache) Invalidate(service string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[serviceBaseName(service)]++
+}
+
+// InvalidateRequest drops the cached result of one call, service must include the version, e.g. "Shops/GetShopCatalogue v2".
+func (c *Cache) InvalidateRequest(service string, request interface{}) {
+	if key, err := c.key(service, request); err == nil {
+		c.store.Delete(key)
+	}
+}
+
+// Clear drops every cached result.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+}
+
+// Stats returns how calls through the cache were answered so far.
+func (c *Cache) Stats() CacheStats {
+	return CacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Coalesced: c.coalesced.Load(),
+	}
+}
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+type memoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates the in-memory CacheStore used by default, expired entries are swept as new ones are added.
+func NewMemoryStore() CacheStore {
+	return &memoryStore{
+		entries:   make(map[string]memoryEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *memoryStore) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(s.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (s *memoryStore) Set(key string, value []byte, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) > memoryStoreSweepGap {
+		for k, entry := range s.entries {
+			if now.After(entry.expires) {
+				delete(s.entries, k)
+			}
+		}
+		s.lastSweep = now
+	}
+	s.entries[key] = memoryEntry{value: value, expires: now.Add(ttl)}
+}
+
+func (s *memoryStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
@@ -0,0 +1,126 @@
+package rlapi
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingServer answers every call from results after a short delay and counts the calls per service.
+func countingServer(results map[string]string, calls map[string]*atomic.Int32) Interceptor {
+	return func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+		calls[info.Service].Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return json.Unmarshal([]byte(results[info.Service]), result)
+	}
+}
+
+func TestCache_CoalesceAndHit(t *testing.T) {
+	calls := map[string]*atomic.Int32{
+		"Playlists/GetActivePlaylists v1": {},
+		"Players/GetProfile v1":           {},
+	}
+	rpc := newPsyNetRPC(nil, "test-player", NewPsyNet())
+	cache := NewCache(CacheOptions{})
+	rpc.Use(cache.Interceptor())
+	rpc.Use(countingServer(map[string]string{
+		"Playlists/GetActivePlaylists v1": `{"CasualPlaylists":[{"NodeID":"OnesCasual","Playlist":1}],"XPLevelUnlocked":20,"Season":12}`,
+		"Players/GetProfile v1":           `{"PlayerData":[]}`,
+	}, calls))
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			playlists, err := rpc.GetActivePlaylists(context.Background())
+			if err != nil {
+				t.Errorf("GetActivePlaylists() error = %v", err)
+				return
+			}
+			if len(playlists.CasualPlaylists) != 1 || playlists.XPLevelUnlocked != 20 {
+				t.Errorf("playlists = %+v", playlists)
+			}
+		}()
+	}
+	wg.Wait()
+
+	playlists, err := rpc.GetActivePlaylists(context.Background())
+	if err != nil {
+		t.Fatalf("GetActivePlaylists() error = %v", err)
+	}
+	if string(playlists.Extra["Season"]) != "12" {
+		t.Errorf("cached Extra = %v, want Season kept", playlists.Extra)
+	}
+	if n := calls["Playlists/GetActivePlaylists v1"].Load(); n != 1 {
+		t.Errorf("server calls = %d, want 1", n)
+	}
+	stats := cache.Stats()
+	if stats.Misses != 1 || stats.Hits+stats.Coalesced != 10 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+
+	// services without a TTL always reach the server
+	for range 2 {
+		if _, err := rpc.GetProfiles(context.Background(), []PlayerID{"Epic|1|0"}); err != nil {
+			t.Fatalf("GetProfiles() error = %v", err)
+		}
+	}
+	if n := calls["Players/GetProfile v1"].Load(); n != 2 {
+		t.Errorf("uncached server calls = %d, want 2", n)
+	}
+
+	cache.Invalidate("Playlists/GetActivePlaylists")
+	if _, err := rpc.GetActivePlaylists(context.Background()); err != nil {
+		t.Fatalf("GetActivePlaylists() error = %v", err)
+	}
+	if n := calls["Playlists/GetActivePlaylists v1"].Load(); n != 2 {
+		t.Errorf("server calls after Invalidate = %d, want 2", n)
+	}
+}
+
+func TestCache_KeyedByRequest(t *testing.T) {
+	calls := map[string]*atomic.Int32{"Shops/GetShopCatalogue v2": {}}
+	rpc := newPsyNetRPC(nil, "test-player", NewPsyNet())
+	cache := NewCache(CacheOptions{TTLs: map[string]time.Duration{"Shops/GetShopCatalogue": time.Minute}})
+	rpc.Use(cache.Interceptor())
+	rpc.Use(countingServer(map[string]string{"Shops/GetShopCatalogue v2": `{"Catalogues":[]}`}, calls))
+
+	for _, ids := range [][]ShopID{{1}, {2}, {1}} {
+		if _, err := rpc.GetShopCatalogue(context.Background(), ids); err != nil {
+			t.Fatalf("GetShopCatalogue() error = %v", err)
+		}
+	}
+	if n := calls["Shops/GetShopCatalogue v2"].Load(); n != 2 {
+		t.Errorf("server calls = %d, want 2", n)
+	}
+
+	cache.InvalidateRequest("Shops/GetShopCatalogue v2", GetShopCatalogueRequest{ShopIDs: []ShopID{1}})
+	if _, err := rpc.GetShopCatalogue(context.Background(), []ShopID{1}); err != nil {
+		t.Fatalf("GetShopCatalogue() error = %v", err)
+	}
+	if n := calls["Shops/GetShopCatalogue v2"].Load(); n != 3 {
+		t.Errorf("server calls after InvalidateRequest = %d, want 3", n)
+	}
+}
+
+func TestMemoryStore_Expiry(t *testing.T) {
+	store := NewMemoryStore()
+	store.Set("short", []byte("1"), time.Millisecond)
+	store.Set("long", []byte("2"), time.Minute)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := store.Get("short"); ok {
+		t.Error("Expected the short entry to expire")
+	}
+	if value, ok := store.Get("long"); !ok || string(value) != "2" {
+		t.Errorf("Get(long) = %s, %v", value, ok)
+	}
+	store.Delete("long")
+	if _, ok := store.Get("long"); ok {
+		t.Error("Expected the deleted entry to be gone")
+	}
+}
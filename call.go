@@ -0,0 +1,146 @@
+package rlapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// PendingCall represents an in-flight request started with CallAsync.
+type PendingCall struct {
+	ctx  context.Context
+	info *CallInfo
+	done chan struct{}
+	once sync.Once
+
+	// response is routed by request ID on the WebSocket, calls run through Call fill result instead
+	response *PsyResponse
+	result   json.RawMessage
+	chained  bool
+	err      error
+}
+
+// Call sends a request to any PsyNet service and decodes the result into response, for endpoints without a wrapper.
+// For example Call(ctx, "Clubs/InviteToClub", 4, request, &response) calls "Clubs/InviteToClub v4".
+func (p *PsyNetRPC) Call(ctx context.Context, service string, version int, request interface{}, response interface{}) error {
+	if request == nil {
+		request = emptyRequest{}
+	}
+	return p.sendRequestSync(ctx, serviceName(service, version), request, response)
+}
+
+// CallAsync is like Call but returns without waiting for the result, use PendingCall.Wait to get it.
+// The request stays bound to ctx, cancelling it abandons the call. With interceptors, a retry policy covering
+// the service or a non-WebSocket transport, the call runs the same path as Call on its own goroutine so every
+// interceptor sees it. Otherwise it waits for the rate limiter, is written before CallAsync returns and its
+// response is routed straight to the PendingCall.
+func (p *PsyNetRPC) CallAsync(ctx context.Context, service string, version int, request interface{}) *PendingCall {
+	if request == nil {
+		request = emptyRequest{}
+	}
+	name := serviceName(service, version)
+	call := &PendingCall{
+		ctx:  ctx,
+		done: make(chan struct{}),
+	}
+
+	p.mu.Lock()
+	limiter := p.limiter
+	chained := p.transport != nil || len(p.interceptors) > 0 || (p.retryPolicy != nil && p.retryPolicy.idempotent(name))
+	p.mu.Unlock()
+
+	if chained {
+		call.info = &CallInfo{Service: name, Request: request}
+		call.chained = true
+		go func() {
+			if err := p.sendRequestSync(ctx, name, request, &call.result); err != nil {
+				call.fail(err)
+				return
+			}
+			call.complete(&PsyResponse{})
+		}()
+		return call
+	}
+
+	call.info = p.newCallInfo(name, request)
+	if limiter != nil {
+		if err := limiter.wait(ctx, name); err != nil {
+			call.fail(fmt.Errorf("failed to send request for service: %s, err: %w", name, err))
+			return call
+		}
+	}
+
+	if err := p.sendPending(ctx, call.info, &pendingRequest{call: call}); err != nil {
+		call.fail(fmt.Errorf("failed to send async request for service: %s, err: %w", name, err))
+	}
+	return call
+}
+
+// complete delivers the routed response, nil when the connection or ctx ended the call first.
+// Only the first of complete and fail counts, a failed write may race the context expiring the call.
+func (c *PendingCall) complete(response *PsyResponse) {
+	c.once.Do(func() {
+		c.response = response
+		close(c.done)
+	})
+}
+
+func (c *PendingCall) fail(err error) {
+	c.once.Do(func() {
+		c.err = err
+		close(c.done)
+	})
+}
+
+// Done returns a channel that is closed once the call completes.
+func (c *PendingCall) Done() <-chan struct{} {
+	return c.done
+}
+
+// Wait blocks until the call completes or ctx is done, then decodes the result into response.
+func (c *PendingCall) Wait(ctx context.Context, response interface{}) error {
+	select {
+	case <-c.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if c.err != nil {
+		return c.err
+	}
+	if c.response == nil {
+		if c.ctx.Err() != nil {
+			return c.ctx.Err()
+		}
+		return ErrConnectionClosed
+	}
+	if c.response.err != nil {
+		return annotateError(c.response.err, c.info)
+	}
+
+	if c.chained {
+		if response == nil || len(c.result) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(c.result, response); err != nil {
+			return fmt.Errorf("failed to unmarshal response for service: %s, err: %w", c.info.Service, err)
+		}
+		return nil
+	}
+	return annotateError(decodeResponse(c.response.body, response), c.info)
+}
+
+// serviceName joins a service and version into the form used by the PsyService header.
+func serviceName(service string, version int) string {
+	return fmt.Sprintf("%s v%d", service, version)
+}
+
+// serviceBaseName strips the version suffix, "Party/System v1" becomes "Party/System".
+func serviceBaseName(service string) string {
+	if i := strings.IndexByte(service, ' '); i != -1 {
+		return service[:i]
+	}
+	return service
+}
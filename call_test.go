@@ -0,0 +1,146 @@
+package rlapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestPsyNetRPC_Call(t *testing.T) {
+	mockServer := NewMockWSServer()
+	defer mockServer.Close()
+
+	psyNet := NewPsyNet()
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-player", "test-token", "test-session")
+	if err != nil {
+		t.Fatalf("Failed to establish socket: %v", err)
+	}
+
+	go rpc.readMessages()
+	defer rpc.Close()
+
+	mockServer.SetResponse("PsyNetMessage_X_0", &PsyResponse{
+		Result: json.RawMessage(`{"Result":{"ClubID":42}}`),
+	})
+	mockServer.SetResponse("PsyNetMessage_X_1", &PsyResponse{
+		Result: json.RawMessage(`{"Result":{"ClubID":43}}`),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var result struct {
+		ClubID ClubID
+	}
+	if err := rpc.Call(ctx, "Clubs/GetClubDetails", 1, map[string]interface{}{}, &result); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if result.ClubID != 42 {
+		t.Errorf("ClubID = %d, want 42", result.ClubID)
+	}
+
+	call := rpc.CallAsync(ctx, "Clubs/GetClubDetails", 1, nil)
+	select {
+	case <-call.Done():
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for async call")
+	}
+	if err := call.Wait(ctx, &result); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if result.ClubID != 43 {
+		t.Errorf("ClubID = %d, want 43", result.ClubID)
+	}
+}
+
+func TestPsyNetRPC_CallAsyncPending(t *testing.T) {
+	mockServer := NewMockWSServer()
+	defer mockServer.Close()
+
+	psyNet := NewPsyNet()
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-player", "test-token", "test-session")
+	if err != nil {
+		t.Fatalf("Failed to establish socket: %v", err)
+	}
+
+	go rpc.readMessages()
+	defer rpc.Close()
+
+	mockServer.SetResponse("PsyNetMessage_X_0", &PsyResponse{
+		Result: json.RawMessage(`{"Error":{"Type":"ClubNotFound","Message":"no club"}}`),
+	})
+
+	call := rpc.CallAsync(context.Background(), "Clubs/GetClubDetails", 1, nil)
+	var psyErr *PsyNetError
+	if err := call.Wait(context.Background(), nil); !errors.As(err, &psyErr) || psyErr.Type != "ClubNotFound" || psyErr.Service != "Clubs/GetClubDetails v1" {
+		t.Errorf("Wait() error = %v, want ClubNotFound", err)
+	}
+
+	// unanswered calls wait in the pending map without a goroutine of their own
+	ctx, cancel := context.WithCancel(context.Background())
+	before := runtime.NumGoroutine()
+	calls := make([]*PendingCall, 20)
+	for i := range calls {
+		calls[i] = rpc.CallAsync(ctx, "Clubs/GetClubDetails", 1, nil)
+	}
+
+	rpc.mu.Lock()
+	pending := len(rpc.pendingReqs)
+	rpc.mu.Unlock()
+	if pending != len(calls) {
+		t.Errorf("pending requests = %d, want %d", pending, len(calls))
+	}
+	if after := runtime.NumGoroutine(); after > before+2 {
+		t.Errorf("goroutines = %d, want about %d", after, before)
+	}
+
+	cancel()
+	for _, call := range calls {
+		if err := call.Wait(context.Background(), nil); !errors.Is(err, context.Canceled) {
+			t.Errorf("Wait() error = %v, want canceled", err)
+		}
+	}
+}
+
+func TestPsyNetRPC_CallAsyncInterceptors(t *testing.T) {
+	mockServer := NewMockWSServer()
+	defer mockServer.Close()
+
+	psyNet := NewPsyNet()
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-player", "test-token", "test-session")
+	if err != nil {
+		t.Fatalf("Failed to establish socket: %v", err)
+	}
+
+	go rpc.readMessages()
+	defer rpc.Close()
+
+	mockServer.SetResponse("PsyNetMessage_X_0", &PsyResponse{
+		Result: json.RawMessage(`{"Result":{"ClubID":43}}`),
+	})
+
+	var seen []string
+	rpc.Use(func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+		err := next(ctx, info, result)
+		raw, _ := json.Marshal(result)
+		seen = append(seen, info.Service+" "+string(raw))
+		return err
+	})
+
+	call := rpc.CallAsync(context.Background(), "Clubs/GetClubDetails", 1, nil)
+	var result struct {
+		ClubID int `json:"ClubID"`
+	}
+	if err := call.Wait(context.Background(), &result); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if result.ClubID != 43 {
+		t.Errorf("ClubID = %d, want 43", result.ClubID)
+	}
+	if len(seen) != 1 || seen[0] != `Clubs/GetClubDetails v1 {"ClubID":43}` {
+		t.Errorf("interceptor saw %v, want the async call and its result", seen)
+	}
+}
@@ -0,0 +1,305 @@
+package rlapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+const redactedValue = "REDACTED"
+
+// ErrCassetteMiss is returned by a replayed call that has no recorded counterpart.
+var ErrCassetteMiss = errors.New("no recorded response")
+
+// CassetteEntryKind tells recorded calls and pushes apart.
+type CassetteEntryKind string
+
+const (
+	CassetteCall CassetteEntryKind = "call"
+	CassettePush CassetteEntryKind = "push"
+)
+
+// CassetteEntry is a single line of a cassette.
+type CassetteEntry struct {
+	Kind    CassetteEntryKind `json:"Kind"`
+	Service string            `json:"Service"`
+	// Offset is the time since recording started.
+	Offset   time.Duration   `json:"Offset"`
+	Request  json.RawMessage `json:"Request,omitempty"`
+	Response json.RawMessage `json:"Response,omitempty"`
+	Error    *PsyNetError    `json:"Error,omitempty"`
+}
+
+// sensitiveKeys are redacted wherever they appear in a recorded body, matched case-insensitively.
+var sensitiveKeys = []string{"token", "ticket", "secret", "password", "sessionid", "session_id", "authcode", "auth_code", "exchangecode", "exchange_code"}
+
+// Recorder writes PsyNet traffic to a JSONL cassette with credentials redacted, see LoadCassette to replay it.
+type Recorder struct {
+	start time.Time
+
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewRecorder creates a recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{
+		start: time.Now(),
+		enc:   json.NewEncoder(w),
+	}
+}
+
+// Err returns the first error encountered while writing the cassette.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Interceptor records every call with its result or PsyNet error, add it with PsyNet.Use or PsyNetRPC.Use.
+// Calls failing for other reasons, e.g. transport errors, are not recorded.
+func (r *Recorder) Interceptor() Interceptor {
+	return func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+		err := next(ctx, info, result)
+
+		entry := &CassetteEntry{
+			Kind:    CassetteCall,
+			Service: info.Service,
+			Offset:  time.Since(r.start),
+		}
+
+		var psyErr *PsyNetError
+		switch {
+		case err == nil:
+			entry.Response = redactJSON(result)
+		case errors.As(err, &psyErr):
+			entry.Error = &PsyNetError{Type: psyErr.Type, Message: psyErr.Message}
+		default:
+			return err
+		}
+		entry.Request = redactJSON(info.Request)
+
+		r.write(entry)
+		return err
+	}
+}
+
+// RecordPushes records messages pushed over the connection until ctx is done.
+func (r *Recorder) RecordPushes(ctx context.Context, rpc *PsyNetRPC) error {
+	sub := rpc.Subscribe(SubscribeOptions{Buffer: dispatcherBuffer})
+	defer sub.Close()
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return sub.Err()
+			}
+			if event.Type != EventTypeMessage {
+				continue
+			}
+
+			push, err := parsePushMessage(event.Content)
+			if err != nil {
+				rpc.logger.Warn("failed to record push message", slog.Any("err", err))
+				continue
+			}
+			r.write(&CassetteEntry{
+				Kind:     CassettePush,
+				Service:  push.Service,
+				Offset:   time.Since(r.start),
+				Response: redactJSON(push.Body),
+			})
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (r *Recorder) write(entry *CassetteEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	if err := r.enc.Encode(entry); err != nil {
+		r.err = fmt.Errorf("failed to write cassette entry: %w", err)
+	}
+}
+
+// Cassette replays recorded traffic, see PsyNet.NewReplayRPC.
+type Cassette struct {
+	pushes []*CassetteEntry
+
+	mu    sync.Mutex
+	calls map[string][]*CassetteEntry
+}
+
+// LoadCassette reads a cassette written by a Recorder.
+func LoadCassette(r io.Reader) (*Cassette, error) {
+	cassette := &Cassette{calls: make(map[string][]*CassetteEntry)}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var entry CassetteEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette line %d: %w", line, err)
+		}
+
+		switch entry.Kind {
+		case CassetteCall:
+			key := cassetteKey(entry.Service, entry.Request)
+			cassette.calls[key] = append(cassette.calls[key], &entry)
+		case CassettePush:
+			cassette.pushes = append(cassette.pushes, &entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	return cassette, nil
+}
+
+// NewReplayRPC creates a connection answering service calls from the cassette instead of the network.
+func (p *PsyNet) NewReplayRPC(cassette *Cassette, playerID PlayerID) *PsyNetRPC {
+	rpc := newPsyNetRPC(nil, playerID, p)
+	rpc.transport = cassette
+	return rpc
+}
+
+// Interceptor answers calls from the cassette without calling next, add it with PsyNet.Use to replay HTTP API calls such as AuthPlayer.
+func (c *Cassette) Interceptor() Interceptor {
+	return func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+		return c.call(ctx, info, result)
+	}
+}
+
+// call answers a call with the recorded response matching its service and normalized body.
+// Repeated calls consume recordings in order, the last one is replayed once the others are used up.
+func (c *Cassette) call(ctx context.Context, info *CallInfo, result interface{}) error {
+	key := cassetteKey(info.Service, redactJSON(info.Request))
+
+	c.mu.Lock()
+	entries := c.calls[key]
+	if len(entries) == 0 {
+		c.mu.Unlock()
+		return fmt.Errorf("%w for service: %s", ErrCassetteMiss, info.Service)
+	}
+	entry := entries[0]
+	if len(entries) > 1 {
+		c.calls[key] = entries[1:]
+	}
+	c.mu.Unlock()
+
+	if entry.Error != nil {
+		return &PsyNetError{Type: entry.Error.Type, Message: entry.Error.Message}
+	}
+	if err := json.Unmarshal(entry.Response, result); err != nil {
+		return fmt.Errorf("failed to unmarshal recorded result: %w", err)
+	}
+	return nil
+}
+
+// ReplayPushes re-emits recorded pushes to the connection's subscribers with their original relative timing.
+func (c *Cassette) ReplayPushes(ctx context.Context, rpc *PsyNetRPC) error {
+	start := time.Now()
+	for _, entry := range c.pushes {
+		if wait := entry.Offset - time.Since(start); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+
+		rpc.publish(&Event{
+			Type:    EventTypeMessage,
+			Service: entry.Service,
+			Content: fmt.Sprintf("PsyService: %s\r\n\r\n%s", entry.Service, entry.Response),
+		})
+	}
+	return nil
+}
+
+func cassetteKey(service string, body json.RawMessage) string {
+	return service + "\n" + string(body)
+}
+
+// redactJSON encodes v as canonical JSON, object keys sorted, with sensitive values replaced.
+func redactJSON(v interface{}) json.RawMessage {
+	var data []byte
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case json.RawMessage:
+		data = v
+	case *json.RawMessage:
+		data = *v
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return nil
+		}
+	}
+
+	// numbers are kept as written, IDs don't fit a float64
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveKey(key) {
+				if _, ok := field.(string); ok {
+					v[key] = redactedValue
+					continue
+				}
+			}
+			v[key] = redactValue(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package rlapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stubTransport struct {
+	results map[string]string
+}
+
+func (t *stubTransport) call(ctx context.Context, info *CallInfo, result interface{}) error {
+	response, ok := t.results[info.Service]
+	if !ok {
+		return &PsyNetError{Type: "UnknownService"}
+	}
+	return json.Unmarshal([]byte(response), result)
+}
+
+func TestRedactJSON(t *testing.T) {
+	got := redactJSON(map[string]interface{}{
+		"PlayerID":   "Epic|123",
+		"AuthTicket": "secret-ticket",
+		"AccountID":  76561198000000001,
+		"Nested":     []interface{}{map[string]interface{}{"PsyToken": "secret-token"}},
+	})
+
+	want := `{"AccountID":76561198000000001,"AuthTicket":"REDACTED","Nested":[{"PsyToken":"REDACTED"}],"PlayerID":"Epic|123"}`
+	if string(got) != want {
+		t.Errorf("redactJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	psyNet := NewPsyNet()
+
+	rpc := psyNet.newHTTPRPC("test-player", "test-token", "test-session")
+	rpc.transport = &stubTransport{results: map[string]string{
+		"Skills/GetPlayerSkill v1": `{"Skills":[{"Playlist":10,"MMR":1200.5}],"SessionToken":"secret"}`,
+	}}
+
+	var buf bytes.Buffer
+	recorder := NewRecorder(&buf)
+	rpc.Use(recorder.Interceptor())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	recording := make(chan error, 1)
+	go func() {
+		recording <- recorder.RecordPushes(ctx, rpc)
+	}()
+
+	var skills json.RawMessage
+	request := map[string]string{"PlayerID": "Epic|123"}
+	if err := rpc.Call(context.Background(), "Skills/GetPlayerSkill", 1, request, &skills); err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if err := rpc.Call(context.Background(), "Missing/Service", 1, nil, nil); err == nil {
+		t.Fatal("Expected unknown service error")
+	}
+
+	// Wait for the recorder's subscription before pushing
+	for {
+		rpc.subsMu.RLock()
+		subscribed := len(rpc.subs) > 1
+		rpc.subsMu.RUnlock()
+		if subscribed {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	rpc.publish(&Event{Type: EventTypeMessage, Service: PushPartySystem, Content: "PsyService: " + PushPartySystem + "\r\n\r\n{\"Message\":\"hi\"}"})
+
+	// Wait for the push to be written
+	for {
+		recorder.mu.Lock()
+		lines := strings.Count(buf.String(), "\n")
+		recorder.mu.Unlock()
+		if lines == 3 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-recording
+
+	if strings.Contains(buf.String(), `"secret"`) {
+		t.Errorf("cassette contains unredacted secret: %s", buf.String())
+	}
+
+	cassette, err := LoadCassette(&buf)
+	if err != nil {
+		t.Fatalf("LoadCassette() error = %v", err)
+	}
+
+	replay := psyNet.NewReplayRPC(cassette, "test-player")
+	if replay.Transport() != TransportReplay {
+		t.Errorf("Transport() = %d, want %d", replay.Transport(), TransportReplay)
+	}
+
+	var replayed struct {
+		Skills []struct {
+			Playlist int
+			MMR      float64
+		}
+	}
+	if err := replay.Call(context.Background(), "Skills/GetPlayerSkill", 1, map[string]string{"PlayerID": "Epic|123"}, &replayed); err != nil {
+		t.Fatalf("replayed Call() error = %v", err)
+	}
+	if len(replayed.Skills) != 1 || replayed.Skills[0].MMR != 1200.5 {
+		t.Errorf("replayed result = %+v", replayed)
+	}
+
+	var psyErr *PsyNetError
+	if err := replay.Call(context.Background(), "Missing/Service", 1, nil, nil); !errors.As(err, &psyErr) || psyErr.Type != "UnknownService" {
+		t.Errorf("replayed error = %v, want UnknownService", err)
+	}
+	if err := replay.Call(context.Background(), "Skills/GetPlayerSkill", 1, map[string]string{"PlayerID": "Epic|456"}, &replayed); !errors.Is(err, ErrCassetteMiss) {
+		t.Errorf("unrecorded call error = %v, want ErrCassetteMiss", err)
+	}
+
+	if err := cassette.ReplayPushes(context.Background(), replay); err != nil {
+		t.Fatalf("ReplayPushes() error = %v", err)
+	}
+	select {
+	case event := <-replay.Events():
+		if event.Service != PushPartySystem || !strings.HasSuffix(event.Content, `{"Message":"hi"}`) {
+			t.Errorf("replayed push = %+v", event)
+		}
+	default:
+		t.Error("Expected replayed push event")
+	}
+}
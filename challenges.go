@@ -2,6 +2,7 @@ package rlapi
 
 import (
 	"context"
+	"encoding/json"
 )
 
 type ChallengeID int
@@ -33,7 +34,7 @@ type ChallengeRequirement struct {
 // ChallengeRewards represents rewards for completing a challenge
 type ChallengeRewards struct {
 	XP       int                      `json:"XP"`
-	Currency []interface{}            `json:"Currency"`
+	Currency []json.RawMessage        `json:"Currency"`
 	Products []ChallengeRewardProduct `json:"Products"`
 	Pips     int                      `json:"Pips"`
 }
@@ -79,6 +80,8 @@ type GetActiveChallengesRequest struct {
 
 type GetActiveChallengesResponse struct {
 	Challenges []Challenge `json:"Challenges"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type PlayerProgressRequest struct {
@@ -88,6 +91,8 @@ type PlayerProgressRequest struct {
 
 type PlayerProgressResponse struct {
 	ProgressData []ChallengeProgress `json:"ProgressData"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type CollectRewardRequest struct {
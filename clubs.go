@@ -2,6 +2,7 @@ package rlapi
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 )
 
@@ -9,21 +10,21 @@ type ClubID int
 
 // ClubDetails represents detailed information about a club
 type ClubDetails struct {
-	ClubID              ClubID        `json:"ClubID"`
-	ClubName            string        `json:"ClubName"`
-	ClubTag             string        `json:"ClubTag"`
-	PrimaryColor        int           `json:"PrimaryColor"`
-	AccentColor         int           `json:"AccentColor"`
-	EquippedTitle       string        `json:"EquippedTitle"`
-	OwnerPlayerID       PlayerID      `json:"OwnerPlayerID"`
-	Members             []ClubMember  `json:"Members"`
-	Badges              []ClubBadge   `json:"Badges"`
-	Flags               []interface{} `json:"Flags"`
-	Verified            bool          `json:"bVerified"`
-	CreatedTime         int           `json:"CreatedTime"`
-	LastUpdatedTime     int           `json:"LastUpdatedTime"`
-	NameLastUpdatedTime int           `json:"NameLastUpdatedTime"`
-	DeletedTime         int           `json:"DeletedTime"`
+	ClubID              ClubID            `json:"ClubID"`
+	ClubName            string            `json:"ClubName"`
+	ClubTag             string            `json:"ClubTag"`
+	PrimaryColor        int               `json:"PrimaryColor"`
+	AccentColor         int               `json:"AccentColor"`
+	EquippedTitle       string            `json:"EquippedTitle"`
+	OwnerPlayerID       PlayerID          `json:"OwnerPlayerID"`
+	Members             []ClubMember      `json:"Members"`
+	Badges              []ClubBadge       `json:"Badges"`
+	Flags               []json.RawMessage `json:"Flags"`
+	Verified            bool              `json:"bVerified"`
+	CreatedTime         int               `json:"CreatedTime"`
+	LastUpdatedTime     int               `json:"LastUpdatedTime"`
+	NameLastUpdatedTime int               `json:"NameLastUpdatedTime"`
+	DeletedTime         int               `json:"DeletedTime"`
 }
 
 // ClubMember represents a member of a club
@@ -107,6 +108,8 @@ type GetClubDetailsRequest struct {
 
 type GetClubDetailsResponse struct {
 	ClubDetails ClubDetails `json:"ClubDetails"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetPlayerClubDetailsRequest struct {
@@ -115,10 +118,14 @@ type GetPlayerClubDetailsRequest struct {
 
 type GetPlayerClubDetailsResponse struct {
 	ClubDetails ClubDetails `json:"ClubDetails"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetClubInvitesResponse struct {
 	ClubInvites []ClubInvite `json:"ClubInvites"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type CreateClubRequest struct {
@@ -130,6 +137,8 @@ type CreateClubRequest struct {
 
 type CreateClubResponse struct {
 	ClubDetails ClubDetails `json:"ClubDetails"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type UpdateClubRequest struct {
@@ -142,6 +151,8 @@ type UpdateClubRequest struct {
 
 type UpdateClubResponse struct {
 	ClubDetails ClubDetails `json:"ClubDetails"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type InviteToClubRequest struct {
@@ -155,18 +166,24 @@ type AcceptClubInviteRequest struct {
 type AcceptClubInviteResponse struct {
 	Success     bool        `json:"Success"`
 	ClubDetails ClubDetails `json:"ClubDetails"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // GetStatsResponse represents the complete statistics data for a club member
 type GetStatsResponse struct {
 	CareerStats            ClubCareerStats     `json:"CareerStats"`
 	SeasonalStats          []ClubSeasonalStat  `json:"SeasonalStats"`
-	PreviousSeasonalBadges []interface{}       `json:"PreviousSeasonalBadges"`
+	PreviousSeasonalBadges []json.RawMessage   `json:"PreviousSeasonalBadges"`
 	SeasonalTitles         []ClubSeasonalTitle `json:"SeasonalTitles"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetClubTitleInstancesResponse struct {
 	ClubTitles []string `json:"ClubTitles"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type RejectClubInviteRequest struct {
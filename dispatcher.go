@@ -0,0 +1,152 @@
+package rlapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+const dispatcherBuffer = 256
+
+// PushMessage represents a message pushed by the server with its parsed headers and raw JSON body.
+type PushMessage struct {
+	Service string
+	Headers map[string]string
+	Body    json.RawMessage
+}
+
+type pushHandler func(context.Context, *PushMessage) error
+
+// Dispatcher routes server-pushed messages to handlers registered per service.
+type Dispatcher struct {
+	rpc    *PsyNetRPC
+	logger *slog.Logger
+
+	mu       sync.RWMutex
+	handlers map[string][]pushHandler
+	fallback []func(context.Context, *PushMessage)
+}
+
+// NewDispatcher creates a dispatcher for messages pushed over the given connection, see Dispatcher.Run.
+func NewDispatcher(rpc *PsyNetRPC) *Dispatcher {
+	return &Dispatcher{
+		rpc:      rpc,
+		logger:   rpc.logger,
+		handlers: make(map[string][]pushHandler),
+	}
+}
+
+// On registers a handler for pushes of the given service with or without version, e.g. "Party/System".
+func (d *Dispatcher) On(service string, handler func(context.Context, *PushMessage)) {
+	d.add(service, func(ctx context.Context, push *PushMessage) error {
+		handler(ctx, push)
+		return nil
+	})
+}
+
+// OnPush registers a handler for pushes of the given service that decodes the body into T first.
+// Pushes that fail to decode skip the handler and are reported by Dispatch.
+func OnPush[T any](d *Dispatcher, service string, handler func(context.Context, *T)) {
+	d.add(service, func(ctx context.Context, push *PushMessage) error {
+		var payload T
+		if err := json.Unmarshal(push.Body, &payload); err != nil {
+			return fmt.Errorf("failed to decode %s payload: %w", push.Service, err)
+		}
+		handler(ctx, &payload)
+		return nil
+	})
+}
+
+func (d *Dispatcher) add(service string, handler pushHandler) {
+	service = serviceBaseName(service)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[service] = append(d.handlers[service], handler)
+}
+
+// OnUnknown registers a catch-all handler for pushes without a registered service handler.
+func (d *Dispatcher) OnUnknown(handler func(context.Context, *PushMessage)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fallback = append(d.fallback, handler)
+}
+
+// Run subscribes to the connection and dispatches pushed messages until ctx is done.
+// Handlers are called sequentially on the calling goroutine.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	sub := d.rpc.Subscribe(SubscribeOptions{Buffer: dispatcherBuffer})
+	defer sub.Close()
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return sub.Err()
+			}
+			if event.Type != EventTypeMessage {
+				continue
+			}
+			if err := d.Dispatch(ctx, event.Content); err != nil {
+				d.logger.Warn("failed to dispatch push message", slog.Any("err", err))
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Dispatch parses a raw pushed message and calls every handler registered for its service.
+// Errors of handlers that couldn't decode the payload are joined.
+func (d *Dispatcher) Dispatch(ctx context.Context, message string) error {
+	push, err := parsePushMessage(message)
+	if err != nil {
+		return err
+	}
+
+	d.mu.RLock()
+	handlers := d.handlers[serviceBaseName(push.Service)]
+	fallback := d.fallback
+	d.mu.RUnlock()
+
+	if len(handlers) == 0 {
+		for _, handler := range fallback {
+			handler(ctx, push)
+		}
+		return nil
+	}
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, push); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func parsePushMessage(message string) (*PushMessage, error) {
+	headers, body, err := splitMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	push := &PushMessage{
+		Service: headers["PsyService"],
+		Headers: headers,
+		Body:    json.RawMessage(body),
+	}
+
+	// some pushes wrap their payload like a response
+	var wrapper struct {
+		Result json.RawMessage `json:"Result"`
+	}
+	if err := json.Unmarshal(push.Body, &wrapper); err == nil && len(wrapper.Result) > 0 {
+		push.Body = wrapper.Result
+	}
+
+	return push, nil
+}
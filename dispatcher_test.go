@@ -0,0 +1,72 @@
+package rlapi
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	d := NewDispatcher(&PsyNetRPC{logger: NewPsyNet().logger})
+
+	var raw *PushMessage
+	d.On(PushPartySystem, func(ctx context.Context, msg *PushMessage) {
+		raw = msg
+	})
+
+	type partySystem struct {
+		PartyID PartyID `json:"PartyID"`
+	}
+	var decoded []*partySystem
+	OnPush(d, PushPartySystem, func(ctx context.Context, msg *partySystem) {
+		decoded = append(decoded, msg)
+	})
+	OnPush(d, "Party/System v1", func(ctx context.Context, msg *[]string) {
+		t.Error("expected the mismatched handler to be skipped")
+	})
+	OnPush(d, PushPartySystem, func(ctx context.Context, msg *partySystem) {
+		decoded = append(decoded, msg)
+	})
+
+	var unknown *PushMessage
+	d.OnUnknown(func(ctx context.Context, msg *PushMessage) {
+		unknown = msg
+	})
+
+	t.Run("known service", func(t *testing.T) {
+		message := "PsyService: Party/System v1\r\nPsyTime: 123\r\n\r\n" + `{"PartyID":"abc"}`
+
+		// the handler that fails to decode is reported without stopping the ones after it
+		if err := d.Dispatch(context.Background(), message); err == nil {
+			t.Error("expected the decode error to be returned")
+		}
+		if raw == nil || string(raw.Body) != `{"PartyID":"abc"}` {
+			t.Errorf("raw = %+v", raw)
+		}
+		if len(decoded) != 2 || decoded[0].PartyID != "abc" || decoded[1].PartyID != "abc" {
+			t.Errorf("decoded = %+v, want both typed handlers called", decoded)
+		}
+		if unknown != nil {
+			t.Error("expected catch-all handler not to be called")
+		}
+	})
+
+	t.Run("unknown service", func(t *testing.T) {
+		message := "PsyService: Foo/Bar v1\r\nPsyTime: 123\r\n\r\n" + `{"Result":{"Foo":1}}`
+
+		if err := d.Dispatch(context.Background(), message); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if unknown == nil {
+			t.Fatal("expected catch-all handler to be called")
+		}
+		if unknown.Service != "Foo/Bar v1" {
+			t.Errorf("Service = %q, want %q", unknown.Service, "Foo/Bar v1")
+		}
+		if unknown.Headers["PsyTime"] != "123" {
+			t.Errorf("PsyTime = %q, want %q", unknown.Headers["PsyTime"], "123")
+		}
+		if string(unknown.Body) != `{"Foo":1}` {
+			t.Errorf("Body = %s, want %s", unknown.Body, `{"Foo":1}`)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package rlapi
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -35,6 +36,8 @@ type TokenResponse struct {
 	App            string `json:"app"`
 	InAppID        string `json:"in_app_id"`
 	DeviceID       string `json:"device_id"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type EOSTokenResponse struct {
@@ -54,6 +57,8 @@ type EOSTokenResponse struct {
 	MergedAccounts    []string `json:"merged_accounts"`
 	ACR               string   `json:"acr"`
 	AuthTime          string   `json:"auth_time"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type DeviceAuthResponse struct {
@@ -62,6 +67,8 @@ type DeviceAuthResponse struct {
 	VerificationURI string `json:"verification_uri"`
 	ExpiresIn       int    `json:"expires_in"`
 	Interval        int    `json:"interval"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // EGS provides an authentication layer for Epic Games Store -- largely adapted from https://github.com/derrod/legendary
@@ -69,11 +76,32 @@ type EGS struct {
 	client *http.Client
 }
 
+// EGSOption configures an EGS client, see NewEGS
+type EGSOption func(*EGS)
+
+// WithEGSHTTPClient overrides the HTTP client, by default a client with a 30s timeout is used
+func WithEGSHTTPClient(client *http.Client) EGSOption {
+	return func(e *EGS) {
+		e.client = client
+	}
+}
+
+// WithEGSNetwork applies the network configuration to EGS requests
+func WithEGSNetwork(config NetworkConfig) EGSOption {
+	return func(e *EGS) {
+		e.client = config.httpClient(defaultHTTPTimeout)
+	}
+}
+
 // NewEGS creates a new Epic Games Store client
-func NewEGS() *EGS {
-	return &EGS{
-		client: &http.Client{Timeout: 30 * time.Second},
+func NewEGS(opts ...EGSOption) *EGS {
+	e := &EGS{
+		client: &http.Client{Timeout: defaultHTTPTimeout},
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 // GetAuthURL returns the EGS login URL for manual browser authentication
@@ -85,7 +113,12 @@ func (e *EGS) GetAuthURL() string {
 
 // AuthenticateWithCode authenticates with EGS using an authorization code
 func (e *EGS) AuthenticateWithCode(authCode string) (*TokenResponse, error) {
-	return e.requestToken(map[string]string{
+	return e.AuthenticateWithCodeContext(context.Background(), authCode)
+}
+
+// AuthenticateWithCodeContext is like AuthenticateWithCode but binds the request to ctx
+func (e *EGS) AuthenticateWithCodeContext(ctx context.Context, authCode string) (*TokenResponse, error) {
+	return e.requestToken(ctx, map[string]string{
 		"grant_type": "authorization_code",
 		"code":       authCode,
 		"token_type": "eg1",
@@ -94,20 +127,25 @@ func (e *EGS) AuthenticateWithCode(authCode string) (*TokenResponse, error) {
 
 // AuthenticateWithRefreshToken authenticates with EGS using a refresh token
 func (e *EGS) AuthenticateWithRefreshToken(refreshToken string) (*TokenResponse, error) {
-	return e.requestToken(map[string]string{
+	return e.AuthenticateWithRefreshTokenContext(context.Background(), refreshToken)
+}
+
+// AuthenticateWithRefreshTokenContext is like AuthenticateWithRefreshToken but binds the request to ctx
+func (e *EGS) AuthenticateWithRefreshTokenContext(ctx context.Context, refreshToken string) (*TokenResponse, error) {
+	return e.requestToken(ctx, map[string]string{
 		"grant_type":    "refresh_token",
 		"refresh_token": refreshToken,
 		"token_type":    "eg1",
 	})
 }
 
-func (e *EGS) requestToken(params map[string]string) (*TokenResponse, error) {
+func (e *EGS) requestToken(ctx context.Context, params map[string]string) (*TokenResponse, error) {
 	form := url.Values{}
 	for k, v := range params {
 		form.Set(k, v)
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("https://%s/account/api/oauth/token", egsOAuthURL), strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("https://%s/account/api/oauth/token", egsOAuthURL), strings.NewReader(form.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -149,7 +187,12 @@ func (e *EGS) requestToken(params map[string]string) (*TokenResponse, error) {
 
 // GetExchangeCode converts an EGS access token into an exchange code for EOS
 func (e *EGS) GetExchangeCode(accessToken string) (string, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://%s/account/api/oauth/exchange", egsOAuthURL), nil)
+	return e.GetExchangeCodeContext(context.Background(), accessToken)
+}
+
+// GetExchangeCodeContext is like GetExchangeCode but binds the request to ctx
+func (e *EGS) GetExchangeCodeContext(ctx context.Context, accessToken string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://%s/account/api/oauth/exchange", egsOAuthURL), nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
@@ -184,7 +227,12 @@ func (e *EGS) GetExchangeCode(accessToken string) (string, error) {
 
 // ExchangeEOSToken exchanges an exchange code for an EOS authentication token
 func (e *EGS) ExchangeEOSToken(exchangeCode string) (*EOSTokenResponse, error) {
-	return e.requestEOSToken(map[string]string{
+	return e.ExchangeEOSTokenContext(context.Background(), exchangeCode)
+}
+
+// ExchangeEOSTokenContext is like ExchangeEOSToken but binds the request to ctx
+func (e *EGS) ExchangeEOSTokenContext(ctx context.Context, exchangeCode string) (*EOSTokenResponse, error) {
+	return e.requestEOSToken(ctx, map[string]string{
 		"grant_type":    "exchange_code",
 		"exchange_code": exchangeCode,
 	})
@@ -192,7 +240,12 @@ func (e *EGS) ExchangeEOSToken(exchangeCode string) (*EOSTokenResponse, error) {
 
 // ExchangeEOSTokenFromSteam exchanges a Steam session ticket for an EOS authentication token
 func (e *EGS) ExchangeEOSTokenFromSteam(steamTicket string) (*EOSTokenResponse, error) {
-	return e.requestEOSToken(map[string]string{
+	return e.ExchangeEOSTokenFromSteamContext(context.Background(), steamTicket)
+}
+
+// ExchangeEOSTokenFromSteamContext is like ExchangeEOSTokenFromSteam but binds the request to ctx
+func (e *EGS) ExchangeEOSTokenFromSteamContext(ctx context.Context, steamTicket string) (*EOSTokenResponse, error) {
+	return e.requestEOSToken(ctx, map[string]string{
 		"grant_type":          "external_auth",
 		"external_auth_type":  "steam_session_ticket",
 		"external_auth_token": steamTicket,
@@ -201,13 +254,18 @@ func (e *EGS) ExchangeEOSTokenFromSteam(steamTicket string) (*EOSTokenResponse,
 
 // RefreshEOSToken refreshes an EOS authentication token using a refresh token
 func (e *EGS) RefreshEOSToken(refreshToken string) (*EOSTokenResponse, error) {
-	return e.requestEOSToken(map[string]string{
+	return e.RefreshEOSTokenContext(context.Background(), refreshToken)
+}
+
+// RefreshEOSTokenContext is like RefreshEOSToken but binds the request to ctx
+func (e *EGS) RefreshEOSTokenContext(ctx context.Context, refreshToken string) (*EOSTokenResponse, error) {
+	return e.requestEOSToken(ctx, map[string]string{
 		"grant_type":    "refresh_token",
 		"refresh_token": refreshToken,
 	})
 }
 
-func (e *EGS) requestEOSToken(params map[string]string) (*EOSTokenResponse, error) {
+func (e *EGS) requestEOSToken(ctx context.Context, params map[string]string) (*EOSTokenResponse, error) {
 	form := url.Values{}
 	for k, v := range params {
 		form.Set(k, v)
@@ -215,7 +273,7 @@ func (e *EGS) requestEOSToken(params map[string]string) (*EOSTokenResponse, erro
 	form.Set("deployment_id", eosDeploymentID)
 	form.Set("scope", "basic_profile")
 
-	req, err := http.NewRequest("POST", "https://api.epicgames.dev/epic/oauth/v2/token", strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.epicgames.dev/epic/oauth/v2/token", strings.NewReader(form.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -249,10 +307,15 @@ func (e *EGS) requestEOSToken(params map[string]string) (*EOSTokenResponse, erro
 
 // RevokeEOSToken revokes an EOS authentication token
 func (e *EGS) RevokeEOSToken(accessToken string) error {
+	return e.RevokeEOSTokenContext(context.Background(), accessToken)
+}
+
+// RevokeEOSTokenContext is like RevokeEOSToken but binds the request to ctx
+func (e *EGS) RevokeEOSTokenContext(ctx context.Context, accessToken string) error {
 	form := url.Values{}
 	form.Set("token", accessToken)
 
-	req, err := http.NewRequest("POST", "https://api.epicgames.dev/epic/oauth/v2/revoke", strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.epicgames.dev/epic/oauth/v2/revoke", strings.NewReader(form.Encode()))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -278,7 +341,12 @@ func (e *EGS) RevokeEOSToken(accessToken string) error {
 //
 // [RFC 8628]: https://datatracker.ietf.org/doc/html/rfc8628
 func (e *EGS) AuthenticateWithDevice() (*DeviceAuthResponse, error) {
-	req, err := http.NewRequest("POST", "https://api.epicgames.dev/epic/oauth/v2/deviceAuthorization", strings.NewReader("client_id="+eosClientID))
+	return e.AuthenticateWithDeviceContext(context.Background())
+}
+
+// AuthenticateWithDeviceContext is like AuthenticateWithDevice but binds the request to ctx
+func (e *EGS) AuthenticateWithDeviceContext(ctx context.Context) (*DeviceAuthResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.epicgames.dev/epic/oauth/v2/deviceAuthorization", strings.NewReader("client_id="+eosClientID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -311,15 +379,25 @@ func (e *EGS) AuthenticateWithDevice() (*DeviceAuthResponse, error) {
 
 // WaitForDeviceAuthorization polls EOS until the user completes authorization at VerificationURI, then returns an EOS token.
 func (e *EGS) WaitForDeviceAuthorization(device *DeviceAuthResponse) (*EOSTokenResponse, error) {
+	return e.WaitForDeviceAuthorizationContext(context.Background(), device)
+}
+
+// WaitForDeviceAuthorizationContext is like WaitForDeviceAuthorization but stops polling once ctx is done.
+func (e *EGS) WaitForDeviceAuthorizationContext(ctx context.Context, device *DeviceAuthResponse) (*EOSTokenResponse, error) {
 	for range device.ExpiresIn / device.Interval {
-		token, err := e.requestEOSToken(map[string]string{
+		token, err := e.requestEOSToken(ctx, map[string]string{
 			"grant_type":  "device_code",
 			"device_code": device.DeviceCode,
 		})
 		if err == nil {
 			return token, nil
 		}
-		time.Sleep(time.Duration(device.Interval) * time.Second)
+
+		select {
+		case <-time.After(time.Duration(device.Interval) * time.Second):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 	}
 
 	return nil, fmt.Errorf("device authorization timed out")
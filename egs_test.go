@@ -0,0 +1,62 @@
+package rlapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newBlockingEGS returns an EGS client whose requests reach a server that never answers them.
+func newBlockingEGS(t *testing.T) *EGS {
+	release := make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig.InsecureSkipVerify = true
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	return NewEGS(WithEGSHTTPClient(&http.Client{Transport: transport}))
+}
+
+func TestEGS_ContextCancelsRequest(t *testing.T) {
+	egs := newBlockingEGS(t)
+
+	calls := map[string]func(ctx context.Context) error{
+		"AuthenticateWithCodeContext": func(ctx context.Context) error {
+			_, err := egs.AuthenticateWithCodeContext(ctx, "code")
+			return err
+		},
+		"GetExchangeCodeContext": func(ctx context.Context) error {
+			_, err := egs.GetExchangeCodeContext(ctx, "token")
+			return err
+		},
+		"ExchangeEOSTokenContext": func(ctx context.Context) error {
+			_, err := egs.ExchangeEOSTokenContext(ctx, "code")
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			start := time.Now()
+			if err := call(ctx); !errors.Is(err, context.Canceled) {
+				t.Errorf("error = %v, want canceled", err)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("returned after %v, want it bound to the context", elapsed)
+			}
+		})
+	}
+}
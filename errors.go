@@ -0,0 +1,117 @@
+package rlapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Error categories, match them with errors.Is against errors returned by service calls.
+var (
+	ErrAuthExpired    = errors.New("authentication expired")
+	ErrBanned         = errors.New("banned")
+	ErrRateLimited    = errors.New("rate limited")
+	ErrUnknownService = errors.New("unknown service")
+	ErrTransport      = errors.New("transport failure")
+	ErrValidation     = errors.New("validation failed")
+	ErrServer         = errors.New("server error")
+)
+
+var (
+	// ErrNotConnected is returned when a call is made without an established connection.
+	ErrNotConnected = fmt.Errorf("%w: websocket connection not established", ErrTransport)
+	// ErrConnectionClosed is returned to pending calls when the connection closes before their response arrives.
+	ErrConnectionClosed = fmt.Errorf("%w: connection closed", ErrTransport)
+	// ErrRequestTimeout is returned when no response arrives within the request timeout, see WithRequestTimeout.
+	ErrRequestTimeout = fmt.Errorf("%w: request timed out", ErrTransport)
+)
+
+// PsyNetError represents an error returned by PsyNet in place of a result.
+type PsyNetError struct {
+	Type    string `json:"Type"`
+	Message string `json:"Message"`
+
+	// Service and RequestID identify the call that failed.
+	Service   string `json:"-"`
+	RequestID string `json:"-"`
+}
+
+func (e *PsyNetError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Type, e.Message)
+}
+
+// Is reports whether the error belongs to the target category, e.g. errors.Is(err, ErrValidation).
+// Only types in the category table match, see RegisterErrorType.
+func (e *PsyNetError) Is(target error) bool {
+	return target != nil && errorCategory(e.Type) == target
+}
+
+// errorCategories maps PsyNet error types observed in captured traffic to their category.
+// Types that haven't been observed stay uncategorised rather than being guessed from their name.
+var (
+	errorCategoriesMu sync.RWMutex
+	errorCategories   = map[string]error{
+		"InvalidParameters": ErrValidation,
+	}
+)
+
+// RegisterErrorType classifies a PsyNet error type, e.g. RegisterErrorType("SomeBanType", ErrBanned) once it is confirmed in traffic.
+// category should be one of the categories above, nil removes the type again.
+func RegisterErrorType(errorType string, category error) {
+	errorCategoriesMu.Lock()
+	defer errorCategoriesMu.Unlock()
+	if category == nil {
+		delete(errorCategories, errorType)
+		return
+	}
+	errorCategories[errorType] = category
+}
+
+func errorCategory(errorType string) error {
+	errorCategoriesMu.RLock()
+	defer errorCategoriesMu.RUnlock()
+	return errorCategories[errorType]
+}
+
+// HTTPError represents a non-200 response from the PsyNet HTTP API.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("unexpected status: %s", e.Status)
+}
+
+// Is reports whether the status code belongs to the target category.
+func (e *HTTPError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return target == ErrAuthExpired
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	case http.StatusNotFound:
+		return target == ErrUnknownService
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return target == ErrValidation
+	}
+	return e.StatusCode >= 500 && target == ErrServer
+}
+
+// annotateError attaches the call's service and request ID to PsyNet and signature errors.
+func annotateError(err error, info *CallInfo) error {
+	var psyErr *PsyNetError
+	if errors.As(err, &psyErr) {
+		psyErr.Service = info.Service
+		psyErr.RequestID = info.RequestID
+	}
+
+	var sigErr *SignatureError
+	if errors.As(err, &sigErr) {
+		sigErr.Service = info.Service
+		sigErr.RequestID = info.RequestID
+	}
+	return err
+}
@@ -0,0 +1,115 @@
+package rlapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPsyNetError_Is(t *testing.T) {
+	if !errors.Is(&PsyNetError{Type: "InvalidParameters"}, ErrValidation) {
+		t.Error("expected InvalidParameters to be a validation error")
+	}
+
+	// unobserved types are not guessed from their name
+	for _, errorType := range []string{"BannerNotFound", "PlayerBanned", "InvalidAuthToken", "Whatever"} {
+		err := &PsyNetError{Type: errorType}
+		for _, category := range []error{ErrBanned, ErrAuthExpired, ErrValidation, ErrServer} {
+			if errors.Is(err, category) {
+				t.Errorf("errors.Is(%s, %v) = true, want uncategorised", errorType, category)
+			}
+		}
+	}
+
+	RegisterErrorType("TestAccountBanned", ErrBanned)
+	defer RegisterErrorType("TestAccountBanned", nil)
+	if !errors.Is(&PsyNetError{Type: "TestAccountBanned"}, ErrBanned) {
+		t.Error("expected a registered type to match its category")
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{ErrRequestTimeout, true},
+		{&HTTPError{StatusCode: http.StatusServiceUnavailable}, true},
+		{ErrRateLimitExceeded, true},
+		{ErrConnectionClosed, false},
+		{fmt.Errorf("failed to send request: %w", ErrNotConnected), false},
+		{&PsyNetError{Type: "InvalidParameters"}, false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("IsRetryable(%v) = %t, want %t", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestHTTPError_Is(t *testing.T) {
+	err := error(&HTTPError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Error("expected 429 to be rate limited")
+	}
+	if !IsRetryable(err) {
+		t.Error("expected 429 to be retryable")
+	}
+	if err.Error() != "unexpected status: 429 Too Many Requests" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+
+	err = &HTTPError{StatusCode: http.StatusUnauthorized}
+	if !errors.Is(err, ErrAuthExpired) || IsRetryable(err) {
+		t.Error("expected 401 to be auth expired and not retryable")
+	}
+}
+
+func TestPsyNetRPC_AwaitClosedChannel(t *testing.T) {
+	rpc := &PsyNetRPC{}
+	respCh := make(chan *PsyResponse)
+	close(respCh)
+
+	var result interface{}
+	err := rpc.awaitResponse(context.Background(), respCh, &result)
+	if !errors.Is(err, ErrConnectionClosed) || !errors.Is(err, ErrTransport) {
+		t.Errorf("err = %v, want %v", err, ErrConnectionClosed)
+	}
+}
+
+func TestPsyNetRPC_Retry(t *testing.T) {
+	rpc := newPsyNetRPC(nil, "test-player", NewPsyNet())
+	rpc.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond})
+
+	var calls []*CallInfo
+	rpc.Use(func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+		calls = append(calls, info)
+		if len(calls) < 3 {
+			return &HTTPError{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"}
+		}
+		return nil
+	})
+
+	var result interface{}
+	if err := rpc.sendRequestSync(context.Background(), "Players/GetProfile v1", emptyRequest{}, &result); err != nil {
+		t.Fatalf("sendRequestSync failed: %v", err)
+	}
+	if len(calls) != 3 {
+		t.Fatalf("calls = %d, want 3", len(calls))
+	}
+	if calls[0].RequestID == calls[1].RequestID {
+		t.Error("expected every attempt to use a fresh request ID")
+	}
+
+	calls = nil
+	err := rpc.sendRequestSync(context.Background(), "Products/TradeIn v2", emptyRequest{}, &result)
+	if !errors.Is(err, ErrServer) {
+		t.Errorf("err = %v, want %v", err, ErrServer)
+	}
+	if len(calls) != 1 {
+		t.Errorf("calls = %d, want 1 for non-idempotent service", len(calls))
+	}
+}
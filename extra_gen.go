@@ -0,0 +1,623 @@
+// Code generated by tools/extra. DO NOT EDIT.
+
+package rlapi
+
+func (r *AcceptClubInviteResponse) UnmarshalJSON(data []byte) error {
+	type plain AcceptClubInviteResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r AcceptClubInviteResponse) MarshalJSON() ([]byte, error) {
+	type plain AcceptClubInviteResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *AuthPlayerResponse) UnmarshalJSON(data []byte) error {
+	type plain AuthPlayerResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r AuthPlayerResponse) MarshalJSON() ([]byte, error) {
+	type plain AuthPlayerResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *BrowseTrainingDataResponse) UnmarshalJSON(data []byte) error {
+	type plain BrowseTrainingDataResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r BrowseTrainingDataResponse) MarshalJSON() ([]byte, error) {
+	type plain BrowseTrainingDataResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *CanShowAvatarResponse) UnmarshalJSON(data []byte) error {
+	type plain CanShowAvatarResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r CanShowAvatarResponse) MarshalJSON() ([]byte, error) {
+	type plain CanShowAvatarResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *ClaimEntitlementsResponse) UnmarshalJSON(data []byte) error {
+	type plain ClaimEntitlementsResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r ClaimEntitlementsResponse) MarshalJSON() ([]byte, error) {
+	type plain ClaimEntitlementsResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *CreateClubResponse) UnmarshalJSON(data []byte) error {
+	type plain CreateClubResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r CreateClubResponse) MarshalJSON() ([]byte, error) {
+	type plain CreateClubResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *DeviceAuthResponse) UnmarshalJSON(data []byte) error {
+	type plain DeviceAuthResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r DeviceAuthResponse) MarshalJSON() ([]byte, error) {
+	type plain DeviceAuthResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *EOSTokenResponse) UnmarshalJSON(data []byte) error {
+	type plain EOSTokenResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r EOSTokenResponse) MarshalJSON() ([]byte, error) {
+	type plain EOSTokenResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *FilterContentResponse) UnmarshalJSON(data []byte) error {
+	type plain FilterContentResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r FilterContentResponse) MarshalJSON() ([]byte, error) {
+	type plain FilterContentResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetActiveChallengesResponse) UnmarshalJSON(data []byte) error {
+	type plain GetActiveChallengesResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetActiveChallengesResponse) MarshalJSON() ([]byte, error) {
+	type plain GetActiveChallengesResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetActivePlaylistsResponse) UnmarshalJSON(data []byte) error {
+	type plain GetActivePlaylistsResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetActivePlaylistsResponse) MarshalJSON() ([]byte, error) {
+	type plain GetActivePlaylistsResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetBanStatusResponse) UnmarshalJSON(data []byte) error {
+	type plain GetBanStatusResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetBanStatusResponse) MarshalJSON() ([]byte, error) {
+	type plain GetBanStatusResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetCatalogResponse) UnmarshalJSON(data []byte) error {
+	type plain GetCatalogResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetCatalogResponse) MarshalJSON() ([]byte, error) {
+	type plain GetCatalogResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetClubDetailsResponse) UnmarshalJSON(data []byte) error {
+	type plain GetClubDetailsResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetClubDetailsResponse) MarshalJSON() ([]byte, error) {
+	type plain GetClubDetailsResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetClubInvitesResponse) UnmarshalJSON(data []byte) error {
+	type plain GetClubInvitesResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetClubInvitesResponse) MarshalJSON() ([]byte, error) {
+	type plain GetClubInvitesResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetClubPrivateMatchesResponse) UnmarshalJSON(data []byte) error {
+	type plain GetClubPrivateMatchesResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetClubPrivateMatchesResponse) MarshalJSON() ([]byte, error) {
+	type plain GetClubPrivateMatchesResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetClubTitleInstancesResponse) UnmarshalJSON(data []byte) error {
+	type plain GetClubTitleInstancesResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetClubTitleInstancesResponse) MarshalJSON() ([]byte, error) {
+	type plain GetClubTitleInstancesResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetContainerDropTableResponse) UnmarshalJSON(data []byte) error {
+	type plain GetContainerDropTableResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetContainerDropTableResponse) MarshalJSON() ([]byte, error) {
+	type plain GetContainerDropTableResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetCreatorCodeResponse) UnmarshalJSON(data []byte) error {
+	type plain GetCreatorCodeResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetCreatorCodeResponse) MarshalJSON() ([]byte, error) {
+	type plain GetCreatorCodeResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetCycleDataResponse) UnmarshalJSON(data []byte) error {
+	type plain GetCycleDataResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetCycleDataResponse) MarshalJSON() ([]byte, error) {
+	type plain GetCycleDataResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetGameServerPingListResponse) UnmarshalJSON(data []byte) error {
+	type plain GetGameServerPingListResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetGameServerPingListResponse) MarshalJSON() ([]byte, error) {
+	type plain GetGameServerPingListResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetMatchHistoryResponse) UnmarshalJSON(data []byte) error {
+	type plain GetMatchHistoryResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetMatchHistoryResponse) MarshalJSON() ([]byte, error) {
+	type plain GetMatchHistoryResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetPlayerClubDetailsResponse) UnmarshalJSON(data []byte) error {
+	type plain GetPlayerClubDetailsResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetPlayerClubDetailsResponse) MarshalJSON() ([]byte, error) {
+	type plain GetPlayerClubDetailsResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetPlayerInfoResponse) UnmarshalJSON(data []byte) error {
+	type plain GetPlayerInfoResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetPlayerInfoResponse) MarshalJSON() ([]byte, error) {
+	type plain GetPlayerInfoResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetPlayerPartyInfoResponse) UnmarshalJSON(data []byte) error {
+	type plain GetPlayerPartyInfoResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetPlayerPartyInfoResponse) MarshalJSON() ([]byte, error) {
+	type plain GetPlayerPartyInfoResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetPlayerPrestigeRewardsResponse) UnmarshalJSON(data []byte) error {
+	type plain GetPlayerPrestigeRewardsResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetPlayerPrestigeRewardsResponse) MarshalJSON() ([]byte, error) {
+	type plain GetPlayerPrestigeRewardsResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetPlayerProductsResponse) UnmarshalJSON(data []byte) error {
+	type plain GetPlayerProductsResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetPlayerProductsResponse) MarshalJSON() ([]byte, error) {
+	type plain GetPlayerProductsResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetPlayerSkillResponse) UnmarshalJSON(data []byte) error {
+	type plain GetPlayerSkillResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetPlayerSkillResponse) MarshalJSON() ([]byte, error) {
+	type plain GetPlayerSkillResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetPlayerWalletResponse) UnmarshalJSON(data []byte) error {
+	type plain GetPlayerWalletResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetPlayerWalletResponse) MarshalJSON() ([]byte, error) {
+	type plain GetPlayerWalletResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetPlayersSkillsResponse) UnmarshalJSON(data []byte) error {
+	type plain GetPlayersSkillsResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetPlayersSkillsResponse) MarshalJSON() ([]byte, error) {
+	type plain GetPlayersSkillsResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetPopulationResponse) UnmarshalJSON(data []byte) error {
+	type plain GetPopulationResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetPopulationResponse) MarshalJSON() ([]byte, error) {
+	type plain GetPopulationResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetProductStatusResponse) UnmarshalJSON(data []byte) error {
+	type plain GetProductStatusResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetProductStatusResponse) MarshalJSON() ([]byte, error) {
+	type plain GetProductStatusResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetProfileResponse) UnmarshalJSON(data []byte) error {
+	type plain GetProfileResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetProfileResponse) MarshalJSON() ([]byte, error) {
+	type plain GetProfileResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetPublicTournamentsResponse) UnmarshalJSON(data []byte) error {
+	type plain GetPublicTournamentsResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetPublicTournamentsResponse) MarshalJSON() ([]byte, error) {
+	type plain GetPublicTournamentsResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetRewardContentResponse) UnmarshalJSON(data []byte) error {
+	type plain GetRewardContentResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetRewardContentResponse) MarshalJSON() ([]byte, error) {
+	type plain GetRewardContentResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetScheduleRegionResponse) UnmarshalJSON(data []byte) error {
+	type plain GetScheduleRegionResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetScheduleRegionResponse) MarshalJSON() ([]byte, error) {
+	type plain GetScheduleRegionResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetScheduleResponse) UnmarshalJSON(data []byte) error {
+	type plain GetScheduleResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetScheduleResponse) MarshalJSON() ([]byte, error) {
+	type plain GetScheduleResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetShopCatalogueResponse) UnmarshalJSON(data []byte) error {
+	type plain GetShopCatalogueResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetShopCatalogueResponse) MarshalJSON() ([]byte, error) {
+	type plain GetShopCatalogueResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetShopNotificationsResponse) UnmarshalJSON(data []byte) error {
+	type plain GetShopNotificationsResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetShopNotificationsResponse) MarshalJSON() ([]byte, error) {
+	type plain GetShopNotificationsResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetSkillLeaderboardRankForUsersResponse) UnmarshalJSON(data []byte) error {
+	type plain GetSkillLeaderboardRankForUsersResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetSkillLeaderboardRankForUsersResponse) MarshalJSON() ([]byte, error) {
+	type plain GetSkillLeaderboardRankForUsersResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetSkillLeaderboardResponse) UnmarshalJSON(data []byte) error {
+	type plain GetSkillLeaderboardResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetSkillLeaderboardResponse) MarshalJSON() ([]byte, error) {
+	type plain GetSkillLeaderboardResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetSkillLeaderboardValueForUserResponse) UnmarshalJSON(data []byte) error {
+	type plain GetSkillLeaderboardValueForUserResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetSkillLeaderboardValueForUserResponse) MarshalJSON() ([]byte, error) {
+	type plain GetSkillLeaderboardValueForUserResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetStandardShopsResponse) UnmarshalJSON(data []byte) error {
+	type plain GetStandardShopsResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetStandardShopsResponse) MarshalJSON() ([]byte, error) {
+	type plain GetStandardShopsResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetStatLeaderboardRankForUsersResponse) UnmarshalJSON(data []byte) error {
+	type plain GetStatLeaderboardRankForUsersResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetStatLeaderboardRankForUsersResponse) MarshalJSON() ([]byte, error) {
+	type plain GetStatLeaderboardRankForUsersResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetStatLeaderboardResponse) UnmarshalJSON(data []byte) error {
+	type plain GetStatLeaderboardResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetStatLeaderboardResponse) MarshalJSON() ([]byte, error) {
+	type plain GetStatLeaderboardResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetStatLeaderboardValueForUserResponse) UnmarshalJSON(data []byte) error {
+	type plain GetStatLeaderboardValueForUserResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetStatLeaderboardValueForUserResponse) MarshalJSON() ([]byte, error) {
+	type plain GetStatLeaderboardValueForUserResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetStatsResponse) UnmarshalJSON(data []byte) error {
+	type plain GetStatsResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetStatsResponse) MarshalJSON() ([]byte, error) {
+	type plain GetStatsResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetSubRegionsResponse) UnmarshalJSON(data []byte) error {
+	type plain GetSubRegionsResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetSubRegionsResponse) MarshalJSON() ([]byte, error) {
+	type plain GetSubRegionsResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetTradeInFiltersResponse) UnmarshalJSON(data []byte) error {
+	type plain GetTradeInFiltersResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetTradeInFiltersResponse) MarshalJSON() ([]byte, error) {
+	type plain GetTradeInFiltersResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetTrainingMetadataResponse) UnmarshalJSON(data []byte) error {
+	type plain GetTrainingMetadataResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetTrainingMetadataResponse) MarshalJSON() ([]byte, error) {
+	type plain GetTrainingMetadataResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *GetXPResponse) UnmarshalJSON(data []byte) error {
+	type plain GetXPResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r GetXPResponse) MarshalJSON() ([]byte, error) {
+	type plain GetXPResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *PartyResponse) UnmarshalJSON(data []byte) error {
+	type plain PartyResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r PartyResponse) MarshalJSON() ([]byte, error) {
+	type plain PartyResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *PlayerProgressResponse) UnmarshalJSON(data []byte) error {
+	type plain PlayerProgressResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r PlayerProgressResponse) MarshalJSON() ([]byte, error) {
+	type plain PlayerProgressResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *RegisterTournamentResponse) UnmarshalJSON(data []byte) error {
+	type plain RegisterTournamentResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r RegisterTournamentResponse) MarshalJSON() ([]byte, error) {
+	type plain RegisterTournamentResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *ReportResponse) UnmarshalJSON(data []byte) error {
+	type plain ReportResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r ReportResponse) MarshalJSON() ([]byte, error) {
+	type plain ReportResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *SendPartyChatMessageResponse) UnmarshalJSON(data []byte) error {
+	type plain SendPartyChatMessageResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r SendPartyChatMessageResponse) MarshalJSON() ([]byte, error) {
+	type plain SendPartyChatMessageResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *SendPartyMessageResponse) UnmarshalJSON(data []byte) error {
+	type plain SendPartyMessageResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r SendPartyMessageResponse) MarshalJSON() ([]byte, error) {
+	type plain SendPartyMessageResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *StartMatchmakingResponse) UnmarshalJSON(data []byte) error {
+	type plain StartMatchmakingResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r StartMatchmakingResponse) MarshalJSON() ([]byte, error) {
+	type plain StartMatchmakingResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *TokenResponse) UnmarshalJSON(data []byte) error {
+	type plain TokenResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r TokenResponse) MarshalJSON() ([]byte, error) {
+	type plain TokenResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *TradeInResponse) UnmarshalJSON(data []byte) error {
+	type plain TradeInResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r TradeInResponse) MarshalJSON() ([]byte, error) {
+	type plain TradeInResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *UnlockContainerResponse) UnmarshalJSON(data []byte) error {
+	type plain UnlockContainerResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r UnlockContainerResponse) MarshalJSON() ([]byte, error) {
+	type plain UnlockContainerResponse
+	return marshalExtra(plain(r), r.Extra)
+}
+
+func (r *UpdateClubResponse) UnmarshalJSON(data []byte) error {
+	type plain UpdateClubResponse
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r UpdateClubResponse) MarshalJSON() ([]byte, error) {
+	type plain UpdateClubResponse
+	return marshalExtra(plain(r), r.Extra)
+}
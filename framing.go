@@ -0,0 +1,157 @@
+package rlapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// maxPooledBuffer keeps the odd huge request from pinning its buffer in the pool.
+const maxPooledBuffer = 1 << 20
+
+var (
+	frameDelimiter = []byte("\r\n\r\n")
+	pongPrefix     = []byte("PsyPong:")
+
+	errMissingDelimiter = errors.New("message does not contain expected delimiter")
+)
+
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bufferPool.Put(buf)
+	}
+}
+
+// buildMessage frames a request, headers are written in the given order followed by PsySig when there is a body.
+func (p *PsyNetRPC) buildMessage(headers [][2]string, body interface{}) ([]byte, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	var jsonData []byte
+	var sig string
+	if body != nil {
+		if err := json.NewEncoder(buf).Encode(body); err != nil {
+			return nil, fmt.Errorf("failed to marshal body: %w", err)
+		}
+		jsonData = bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+		sig = generatePsySig(p.sigKey, jsonData)
+	}
+
+	size := len(frameDelimiter) + len(jsonData)
+	for _, header := range headers {
+		size += len(header[0]) + len(header[1]) + 4
+	}
+	if body != nil {
+		size += len("PsySig: \r\n") + len(sig)
+	}
+
+	// the message is handed to the write pump, so it gets its own exactly sized slice
+	message := make([]byte, 0, size)
+	for _, header := range headers {
+		message = appendHeader(message, header[0], header[1])
+	}
+	if body != nil {
+		message = appendHeader(message, "PsySig", sig)
+	}
+	message = append(message, "\r\n"...)
+	message = append(message, jsonData...)
+
+	return message, nil
+}
+
+func appendHeader(message []byte, key, value string) []byte {
+	message = append(message, key...)
+	message = append(message, ": "...)
+	message = append(message, value...)
+	return append(message, "\r\n"...)
+}
+
+// parseMessage reads the routing headers of a message. The body is kept as is,
+// awaitResponse decodes it straight into the caller's result.
+func (p *PsyNetRPC) parseMessage(message []byte) (*PsyResponse, error) {
+	head, body, ok := bytes.Cut(message, frameDelimiter)
+	if !ok {
+		return nil, errMissingDelimiter
+	}
+
+	response := &PsyResponse{body: body}
+	var psyTime, psySig string
+	for len(head) > 0 {
+		var line []byte
+		line, head, _ = bytes.Cut(head, []byte("\r\n"))
+		key, value, ok := bytes.Cut(line, []byte(":"))
+		if !ok {
+			continue
+		}
+
+		// string(key) in a switch doesn't allocate, only the values kept are copied
+		switch string(bytes.TrimSpace(key)) {
+		case "PsyResponseID":
+			response.ResponseID = string(bytes.TrimSpace(value))
+		case "PsyService":
+			response.service = string(bytes.TrimSpace(value))
+		case "PsyTime":
+			psyTime = string(bytes.TrimSpace(value))
+		case "PsySig":
+			psySig = string(bytes.TrimSpace(value))
+		}
+	}
+
+	if p.responseSigKey != "" && !verifyPsySig(p.responseSigKey, psyTime, psySig, body) {
+		response.err = &SignatureError{
+			Service:   response.service,
+			RequestID: response.ResponseID,
+			PsyTime:   psyTime,
+			PsySig:    psySig,
+		}
+	}
+
+	return response, nil
+}
+
+// decodeResponse decodes a response body in one pass, the result goes straight into result.
+func decodeResponse(body []byte, result interface{}) error {
+	envelope := struct {
+		Result interface{}  `json:"Result"`
+		Error  *PsyNetError `json:"Error"`
+	}{Result: result}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if envelope.Error != nil {
+		return envelope.Error
+	}
+	return nil
+}
+
+// splitMessage separates a PsyNet message into its headers and JSON payload.
+func splitMessage(message string) (map[string]string, string, error) {
+	head, body, ok := strings.Cut(message, "\r\n\r\n")
+	if !ok {
+		return nil, "", errMissingDelimiter
+	}
+
+	headers := make(map[string]string)
+	for len(head) > 0 {
+		var line string
+		line, head, _ = strings.Cut(head, "\r\n")
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return headers, body, nil
+}
@@ -0,0 +1,141 @@
+package rlapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseMessage_HeaderSpacing(t *testing.T) {
+	rpc := &PsyNetRPC{}
+	resp, err := rpc.parseMessage([]byte("PsyResponseID:PsyNetMessage_X_7 \r\nPsyService :  Players/GetProfile v1\r\nbroken line\r\n\r\n{}"))
+	if err != nil {
+		t.Fatalf("parseMessage() error = %v", err)
+	}
+	if resp.ResponseID != "PsyNetMessage_X_7" || resp.service != "Players/GetProfile v1" || string(resp.body) != "{}" {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestDecodeResponse_RawResult(t *testing.T) {
+	var result json.RawMessage
+	if err := decodeResponse([]byte(`{"Result":{"ID":90071992547409931}}`), &result); err != nil {
+		t.Fatalf("decodeResponse() error = %v", err)
+	}
+	if string(result) != `{"ID":90071992547409931}` {
+		t.Errorf("result = %s", result)
+	}
+}
+
+// inventoryMessage is a response the size of a large inventory.
+func inventoryMessage(products int) []byte {
+	var body strings.Builder
+	body.WriteString(`{"Result":{"ProductData":[`)
+	for i := range products {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		fmt.Fprintf(&body, `{"ProductID":%d,"InstanceID":"%032x","Attributes":[{"Key":"Painted","Value":"%d"}],"SeriesID":%d,"AddedTimestamp":1700000000,"UpdatedTimestamp":1700000000}`, i, i, i%14, i%40)
+	}
+	body.WriteString(`]}}`)
+	return []byte("PsyTime: 1700000000\r\nPsySig: sig\r\nPsyResponseID: PsyNetMessage_X_1\r\n\r\n" + body.String())
+}
+
+func BenchmarkBuildMessage(b *testing.B) {
+	rpc := &PsyNetRPC{sigKey: psySigKey}
+	headers := [][2]string{{"PsyService", "Products/GetPlayerProducts v2"}, {"PsyRequestID", "PsyNetMessage_X_1"}}
+	request := GetPlayerProductsRequest{PlayerID: "Epic|123|0", UpdatedTimestamp: "1700000000"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := rpc.buildMessage(headers, request); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkBuildMessage_Baseline frames the same request the way it was done before pooling, for comparison.
+func BenchmarkBuildMessage_Baseline(b *testing.B) {
+	headers := map[string]string{"PsyService": "Products/GetPlayerProducts v2", "PsyRequestID": "PsyNetMessage_X_1"}
+	request := GetPlayerProductsRequest{PlayerID: "Epic|123|0", UpdatedTimestamp: "1700000000"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		jsonData, err := json.Marshal(request)
+		if err != nil {
+			b.Fatal(err)
+		}
+		headers["PsySig"] = generatePsySig(psySigKey, jsonData)
+		var message strings.Builder
+		for key, value := range headers {
+			message.WriteString(fmt.Sprintf("%s: %s\r\n", key, value))
+		}
+		message.WriteString("\r\n")
+		message.Write(jsonData)
+		_ = []byte(message.String())
+	}
+}
+
+func BenchmarkReceiveResponse(b *testing.B) {
+	rpc := &PsyNetRPC{}
+	message := inventoryMessage(2000)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(message)))
+	for b.Loop() {
+		resp, err := rpc.parseMessage(message)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result GetPlayerProductsResponse
+		if err := decodeResponse(resp.body, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkReceiveResponse_NoExtra decodes the same response into a result without an Extra field,
+// the difference to BenchmarkReceiveResponse is the cost of keeping unknown members of the result.
+func BenchmarkReceiveResponse_NoExtra(b *testing.B) {
+	rpc := &PsyNetRPC{}
+	message := inventoryMessage(2000)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(message)))
+	for b.Loop() {
+		resp, err := rpc.parseMessage(message)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result struct {
+			ProductData []Product `json:"ProductData"`
+		}
+		if err := decodeResponse(resp.body, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkReceiveResponse_Baseline parses and decodes the same response in two passes through strings, for comparison.
+func BenchmarkReceiveResponse_Baseline(b *testing.B) {
+	message := inventoryMessage(2000)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(message)))
+	for b.Loop() {
+		headers, payload, err := splitMessage(string(message))
+		if err != nil {
+			b.Fatal(err)
+		}
+		var envelope PsyResponse
+		if err := json.Unmarshal([]byte(payload), &envelope); err != nil {
+			b.Fatal(err)
+		}
+		envelope.ResponseID = headers["PsyResponseID"]
+		var result GetPlayerProductsResponse
+		if err := json.Unmarshal(envelope.Result, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
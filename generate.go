@@ -0,0 +1,4 @@
+package rlapi
+
+//go:generate go run ./tools/gen -spec spec -out . -docs REQUESTS.md
+//go:generate go run ./tools/extra -dir . -out extra_gen.go
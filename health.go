@@ -0,0 +1,223 @@
+package rlapi
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// rttWindow is the number of recent ping round trips kept for Health.
+const rttWindow = 64
+
+// rttBucketBounds are the upper bounds of the RTT histogram buckets, a final bucket counts everything above.
+var rttBucketBounds = []time.Duration{
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2500 * time.Millisecond,
+}
+
+// ConnState represents the state of a PsyNetRPC connection.
+type ConnState int
+
+const (
+	// StateConnecting is reported while a dropped connection is being re-established.
+	StateConnecting ConnState = iota
+	// StateConnected is reported while the connection is healthy.
+	StateConnected
+	// StateDegraded is reported when a pong is late, before the pong timeout closes the socket.
+	StateDegraded
+	// StateClosed is reported once the connection is closed for good.
+	StateClosed
+)
+
+func (s ConnState) String() string {
+	switch s {
+	case StateConnecting:
+		return "connecting"
+	case StateConnected:
+		return "connected"
+	case StateDegraded:
+		return "degraded"
+	case StateClosed:
+		return "closed"
+	}
+	return "unknown"
+}
+
+// RTTBucket counts ping round trips up to UpperBound, a zero UpperBound counts everything above the previous bucket.
+type RTTBucket struct {
+	UpperBound time.Duration
+	Count      int
+}
+
+// RTTStats summarizes recent ping round trips.
+type RTTStats struct {
+	Samples int
+	Last    time.Duration
+	Min     time.Duration
+	Max     time.Duration
+	Mean    time.Duration
+	P50     time.Duration
+	P95     time.Duration
+	Buckets []RTTBucket
+}
+
+// InFlightRequest represents a request awaiting its response.
+type InFlightRequest struct {
+	RequestID string
+	Service   string
+	Age       time.Duration
+}
+
+// Health is a snapshot of a connection's health.
+type Health struct {
+	State       ConnState
+	ConnectedAt time.Time
+	Uptime      time.Duration
+	LastPong    time.Time
+	RTT         RTTStats
+	InFlight    []InFlightRequest
+	BytesIn     uint64
+	BytesOut    uint64
+}
+
+type connHealth struct {
+	bytesIn  atomic.Uint64
+	bytesOut atomic.Uint64
+
+	mu          sync.Mutex
+	state       ConnState
+	onChange    []func(old, new ConnState)
+	connectedAt time.Time
+	lastPong    time.Time
+	rtts        [rttWindow]time.Duration
+	rttCount    int
+}
+
+func (h *connHealth) init() {
+	h.state = StateConnected
+	h.connectedAt = time.Now()
+}
+
+func (h *connHealth) connected() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.connectedAt = time.Now()
+}
+
+func (h *connHealth) recordPong(rtt time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastPong = time.Now()
+	h.rtts[h.rttCount%rttWindow] = rtt
+	h.rttCount++
+}
+
+func (h *connHealth) rttStats() RTTStats {
+	n := min(h.rttCount, rttWindow)
+	stats := RTTStats{
+		Samples: n,
+		Buckets: make([]RTTBucket, len(rttBucketBounds)+1),
+	}
+	for i, bound := range rttBucketBounds {
+		stats.Buckets[i].UpperBound = bound
+	}
+	if n == 0 {
+		return stats
+	}
+
+	samples := slices.Clone(h.rtts[:n])
+	stats.Last = h.rtts[(h.rttCount-1)%rttWindow]
+
+	var total time.Duration
+	for _, rtt := range samples {
+		total += rtt
+		i, _ := slices.BinarySearch(rttBucketBounds, rtt)
+		stats.Buckets[i].Count++
+	}
+
+	slices.Sort(samples)
+	stats.Min = samples[0]
+	stats.Max = samples[n-1]
+	stats.Mean = total / time.Duration(n)
+	stats.P50 = samples[(n-1)*50/100]
+	stats.P95 = samples[(n-1)*95/100]
+
+	return stats
+}
+
+// Health returns a snapshot of the connection's state, ping round trips, in-flight requests and traffic.
+func (p *PsyNetRPC) Health() Health {
+	now := time.Now()
+	inFlight := p.inFlight()
+
+	h := &p.health
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	health := Health{
+		State:       h.state,
+		ConnectedAt: h.connectedAt,
+		LastPong:    h.lastPong,
+		RTT:         h.rttStats(),
+		InFlight:    inFlight,
+		BytesIn:     h.bytesIn.Load(),
+		BytesOut:    h.bytesOut.Load(),
+	}
+	if h.state != StateClosed && h.state != StateConnecting {
+		health.Uptime = now.Sub(h.connectedAt)
+	}
+
+	return health
+}
+
+// inFlight lists the pending requests, oldest first.
+func (p *PsyNetRPC) inFlight() []InFlightRequest {
+	now := time.Now()
+
+	p.mu.Lock()
+	inFlight := make([]InFlightRequest, 0, len(p.pendingReqs))
+	for id, req := range p.pendingReqs {
+		inFlight = append(inFlight, InFlightRequest{
+			RequestID: id,
+			Service:   req.service,
+			Age:       now.Sub(req.sentAt),
+		})
+	}
+	p.mu.Unlock()
+
+	slices.SortFunc(inFlight, func(a, b InFlightRequest) int {
+		return int(b.Age - a.Age)
+	})
+	return inFlight
+}
+
+// OnStateChange registers a callback for connection state changes. Callbacks run synchronously,
+// on the goroutine that observed the change, and must not block.
+func (p *PsyNetRPC) OnStateChange(callback func(old, new ConnState)) {
+	p.health.mu.Lock()
+	defer p.health.mu.Unlock()
+	p.health.onChange = append(p.health.onChange, callback)
+}
+
+func (p *PsyNetRPC) setState(state ConnState) {
+	h := &p.health
+	h.mu.Lock()
+	old := h.state
+	if old == state || old == StateClosed {
+		h.mu.Unlock()
+		return
+	}
+	h.state = state
+	callbacks := h.onChange
+	h.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(old, state)
+	}
+}
@@ -0,0 +1,81 @@
+package rlapi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestConnHealth_RTTStats(t *testing.T) {
+	var h connHealth
+	h.init()
+
+	for i := 1; i <= 100; i++ {
+		h.recordPong(time.Duration(i) * time.Millisecond)
+	}
+
+	stats := h.rttStats()
+	if stats.Samples != rttWindow {
+		t.Errorf("Samples = %d, want %d", stats.Samples, rttWindow)
+	}
+	if stats.Last != 100*time.Millisecond {
+		t.Errorf("Last = %v, want 100ms", stats.Last)
+	}
+	if stats.Min != 37*time.Millisecond || stats.Max != 100*time.Millisecond {
+		t.Errorf("Min/Max = %v/%v, want 37ms/100ms", stats.Min, stats.Max)
+	}
+	if stats.P50 < stats.Min || stats.P95 < stats.P50 || stats.Max < stats.P95 {
+		t.Errorf("percentiles out of order: %+v", stats)
+	}
+
+	var total int
+	for _, bucket := range stats.Buckets {
+		total += bucket.Count
+	}
+	if total != rttWindow {
+		t.Errorf("bucket total = %d, want %d", total, rttWindow)
+	}
+}
+
+func TestPsyNetRPC_Health(t *testing.T) {
+	mockServer := NewMockWSServer()
+	defer mockServer.Close()
+
+	psyNet := NewPsyNet()
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-player", "test-token", "test-session")
+	if err != nil {
+		t.Fatalf("Failed to establish socket: %v", err)
+	}
+	go rpc.readMessages()
+
+	var changes []ConnState
+	rpc.OnStateChange(func(old, new ConnState) {
+		changes = append(changes, new)
+	})
+
+	// No response is registered, so the request stays in flight
+	_, err = rpc.sendRequestAsync(context.Background(), "Test/Pending v1", map[string]string{})
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	health := rpc.Health()
+	if health.State != StateConnected {
+		t.Errorf("State = %s, want connected", health.State)
+	}
+	if len(health.InFlight) != 1 || health.InFlight[0].Service != "Test/Pending v1" {
+		t.Errorf("InFlight = %+v, want one Test/Pending v1 request", health.InFlight)
+	}
+	if health.BytesOut == 0 {
+		t.Error("Expected BytesOut to be counted")
+	}
+
+	rpc.Close()
+
+	if health := rpc.Health(); health.State != StateClosed || health.Uptime != 0 {
+		t.Errorf("State = %s, Uptime = %v after close", health.State, health.Uptime)
+	}
+	if len(changes) != 1 || changes[0] != StateClosed {
+		t.Errorf("state changes = %v, want [closed]", changes)
+	}
+}
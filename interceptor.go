@@ -0,0 +1,59 @@
+package rlapi
+
+import (
+	"context"
+	"time"
+)
+
+// CallInfo describes a single request passing through the interceptor chain.
+type CallInfo struct {
+	// Service is the PsyService name, e.g. "Skills/GetPlayerSkill v1", or the joined path for HTTP API calls, e.g. "Auth/AuthPlayer/v2".
+	Service   string
+	RequestID string
+	// Request is the request body, interceptors may replace it before calling next.
+	Request interface{}
+	// Headers are sent in addition to the standard Psy headers.
+	Headers map[string]string
+	// Start is when the call entered the chain.
+	Start time.Time
+}
+
+// Invoker performs the call described by info and decodes the result.
+type Invoker func(ctx context.Context, info *CallInfo, result interface{}) error
+
+// Interceptor wraps every call. It can inspect or modify info before calling next, inspect the decoded result
+// and error after next returns, or short-circuit the call by returning without calling next.
+type Interceptor func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error
+
+// Use adds interceptors around every HTTP API call, connections returned from AuthPlayer afterwards inherit them.
+// Interceptors run in the order they were added, the first being outermost.
+func (p *PsyNet) Use(interceptors ...Interceptor) {
+	p.interceptors = append(p.interceptors[:len(p.interceptors):len(p.interceptors)], interceptors...)
+}
+
+// Use adds interceptors around every service call on this connection, after those inherited from PsyNet.
+func (p *PsyNetRPC) Use(interceptors ...Interceptor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.interceptors = append(p.interceptors[:len(p.interceptors):len(p.interceptors)], interceptors...)
+}
+
+func (p *PsyNetRPC) newCallInfo(service string, data interface{}) *CallInfo {
+	return &CallInfo{
+		Service:   service,
+		RequestID: p.requestID.getID(),
+		Request:   data,
+		Headers:   make(map[string]string),
+		Start:     time.Now(),
+	}
+}
+
+func chainInterceptors(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, info *CallInfo, result interface{}) error {
+			return interceptor(ctx, info, result, next)
+		}
+	}
+	return invoker
+}
@@ -0,0 +1,88 @@
+package rlapi
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPsyNetRPC_Interceptors(t *testing.T) {
+	mockServer := NewMockWSServer()
+	defer mockServer.Close()
+
+	psyNet := NewPsyNet()
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-player", "test-token", "test-session")
+	if err != nil {
+		t.Fatalf("Failed to establish socket: %v", err)
+	}
+
+	go rpc.readMessages()
+	defer rpc.Close()
+
+	mockServer.SetResponse("PsyNetMessage_X_0", &PsyResponse{
+		Result: json.RawMessage(`{"Result":{"Value":1}}`),
+	})
+
+	var order []string
+	var seen *CallInfo
+	rpc.Use(
+		func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+			order = append(order, "outer")
+			info.Headers["X-Trace"] = "abc"
+			return next(ctx, info, result)
+		},
+		func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+			order = append(order, "inner")
+			err := next(ctx, info, result)
+			seen = info
+			return err
+		},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var result struct{ Value int }
+	if err := rpc.sendRequestSync(ctx, "Test/Service v1", map[string]interface{}{}, &result); err != nil {
+		t.Fatalf("sendRequestSync failed: %v", err)
+	}
+
+	if !slices.Equal(order, []string{"outer", "inner"}) {
+		t.Errorf("order = %v, want [outer inner]", order)
+	}
+	if seen == nil || seen.Service != "Test/Service v1" || seen.RequestID != "PsyNetMessage_X_0" {
+		t.Errorf("info = %+v", seen)
+	}
+	if result.Value != 1 {
+		t.Errorf("Value = %d, want 1", result.Value)
+	}
+
+	found := false
+	for _, msg := range mockServer.messages {
+		if strings.Contains(msg, "X-Trace: abc\r\n") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected stamped header to be sent")
+	}
+}
+
+func TestPsyNetRPC_InterceptorShortCircuit(t *testing.T) {
+	rpc := newPsyNetRPC(nil, "test-player", NewPsyNet())
+	rpc.Use(func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+		*(result.(*int)) = 7
+		return nil
+	})
+
+	var result int
+	if err := rpc.sendRequestSync(context.Background(), "Test/Service v1", emptyRequest{}, &result); err != nil {
+		t.Fatalf("sendRequestSync failed: %v", err)
+	}
+	if result != 7 {
+		t.Errorf("result = %d, want 7", result)
+	}
+}
@@ -0,0 +1,168 @@
+package rlapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Result types keep the members they don't model in an Extra field, the generated
+// UnmarshalJSON and MarshalJSON methods in extra_gen.go round-trip them through these helpers.
+// Types nested in a result don't, scanning every element of a large inventory again would
+// cost more than decoding it, StrictDecoder reports their unknown members instead.
+
+var extraFields sync.Map // reflect.Type -> map[string]reflect.Type
+
+func knownFields(t reflect.Type) map[string]reflect.Type {
+	if fields, ok := extraFields.Load(t); ok {
+		return fields.(map[string]reflect.Type)
+	}
+	fields, _ := extraFields.LoadOrStore(t, structFields(t))
+	return fields.(map[string]reflect.Type)
+}
+
+// unmarshalExtra decodes data into v, a pointer to a struct type without methods, and collects the members it has no field for in extra.
+func unmarshalExtra(data []byte, v interface{}, extra *map[string]json.RawMessage) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	// data is valid JSON now, the members are walked in place and only unknown ones are copied
+	start := skipSpace(data, 0)
+	if start == len(data) || data[start] != '{' {
+		return nil
+	}
+
+	fields := knownFields(reflect.TypeOf(v).Elem())
+	*extra = nil
+	forEachMember(data[start:], func(key, value []byte) {
+		if _, ok := fields[string(key)]; ok {
+			return
+		}
+		name := string(key)
+		if bytes.IndexByte(key, '\\') >= 0 {
+			quoted := append(append([]byte{'"'}, key...), '"')
+			if err := json.Unmarshal(quoted, &name); err != nil {
+				return
+			}
+		}
+		if _, ok := lookupField(fields, name); ok {
+			return
+		}
+		if *extra == nil {
+			*extra = make(map[string]json.RawMessage)
+		}
+		(*extra)[name] = append(json.RawMessage(nil), value...)
+	})
+	return nil
+}
+
+// marshalExtra encodes v, a struct without methods, with the members of extra appended in key order.
+// Members that collide with a field are dropped, the field wins.
+func marshalExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	fields := knownFields(reflect.TypeOf(v))
+	keys := make([]string, 0, len(extra))
+	for key, value := range extra {
+		if _, ok := lookupField(fields, key); ok || len(value) == 0 {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return data, nil
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Grow(len(data))
+	buf.Write(data[:len(data)-1])
+	for _, key := range keys {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(extra[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// forEachMember calls fn with the raw key and value of every member of a valid JSON object.
+func forEachMember(data []byte, fn func(key, value []byte)) {
+	i := skipSpace(data, 1)
+	for i < len(data) && data[i] != '}' {
+		keyEnd := skipString(data, i)
+		key := data[i+1 : keyEnd-1]
+		i = skipSpace(data, keyEnd) + 1 // colon
+		i = skipSpace(data, i)
+		valueEnd := skipValue(data, i)
+		fn(key, data[i:valueEnd])
+		i = skipSpace(data, valueEnd)
+		if i < len(data) && data[i] == ',' {
+			i = skipSpace(data, i+1)
+		}
+	}
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+// skipString returns the index after the string starting at data[i].
+func skipString(data []byte, i int) int {
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return i
+}
+
+// skipValue returns the index after the value starting at data[i].
+func skipValue(data []byte, i int) int {
+	switch data[i] {
+	case '"':
+		return skipString(data, i)
+	case '{', '[':
+		depth := 0
+		for i < len(data) {
+			switch data[i] {
+			case '"':
+				i = skipString(data, i)
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+			i++
+		}
+		return i
+	}
+	for i < len(data) && data[i] != ',' && data[i] != '}' && data[i] != ']' && data[i] != ' ' &&
+		data[i] != '\t' && data[i] != '\n' && data[i] != '\r' {
+		i++
+	}
+	return i
+}
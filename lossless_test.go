@@ -0,0 +1,98 @@
+package rlapi
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestResponseExtra_RoundTrip(t *testing.T) {
+	data := `{"CycleID":7,"CycleEndTime":1700000000,"WeekID":2,"WeekEndTime":1700001000,` +
+		`"WeeklyCurrencies":[{"ID":13,"Amount":90071992547409931}],"Weeks":[{"WeekID":1,"Rewards":[]}],` +
+		`"TournamentCurrencyID":13,"SeasonID":"S12","bFinalWeek":true}`
+
+	var resp GetCycleDataResponse
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if resp.CycleID != 7 || len(resp.Weeks) != 1 {
+		t.Errorf("resp = %+v", resp)
+	}
+	if string(resp.Extra["SeasonID"]) != `"S12"` || string(resp.Extra["bFinalWeek"]) != "true" || len(resp.Extra) != 2 {
+		t.Errorf("Extra = %v", resp.Extra)
+	}
+
+	out, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(out) != data {
+		t.Errorf("Marshal() = %s, want %s", out, data)
+	}
+}
+
+func TestResponseExtra_Nested(t *testing.T) {
+	data := `{"Tournaments":[{"ID":1,"Title":"Cup","Bracket":{"Size":16}}],"Season":12}`
+
+	var resp GetPublicTournamentsResponse
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(resp.Extra) != 1 || string(resp.Extra["Season"]) != "12" {
+		t.Errorf("Extra = %v, want Season kept", resp.Extra)
+	}
+	if len(resp.Tournaments) != 1 || resp.Tournaments[0].Title != "Cup" {
+		t.Fatalf("Tournaments = %+v", resp.Tournaments)
+	}
+
+	// only the result keeps unknown members, nested ones are left to StrictDecoder
+	issues := NewStrictDecoder().Check("Tournaments/Search/GetPublicTournaments v1", []byte(data), &GetPublicTournamentsResponse{})
+	if !slices.ContainsFunc(issues, func(issue SchemaIssue) bool { return issue.Path == "$.Tournaments[].Bracket" }) {
+		t.Errorf("Check() = %+v, want the nested Bracket reported", issues)
+	}
+}
+
+func TestResponseExtra_FieldWins(t *testing.T) {
+	resp := GetProfileResponse{
+		PlayerData: []PlayerData{{PlayerID: "Steam|1|0"}},
+		Extra: map[string]json.RawMessage{
+			"playerdata": json.RawMessage(`[]`),
+			"Level":      json.RawMessage(`5`),
+		},
+	}
+
+	out, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"PlayerData":[{"PlayerID":"Steam|1|0","PlayerName":"","PresenceState":"","PresenceInfo":""}],"Level":5}`
+	if string(out) != want {
+		t.Errorf("Marshal() = %s, want %s", out, want)
+	}
+}
+
+// cycleDataMessage embeds a response type, tools/extra leaves it to the promoted methods.
+type cycleDataMessage struct {
+	GetCycleDataResponse
+}
+
+func TestResponseExtra_Embedded(t *testing.T) {
+	data := `{"CycleID":7,"CycleEndTime":1700000000,"WeekID":2,"WeekEndTime":1700001000,` +
+		`"WeeklyCurrencies":null,"Weeks":null,"TournamentCurrencyID":13,"SeasonID":"S12"}`
+
+	var message cycleDataMessage
+	if err := json.Unmarshal([]byte(data), &message); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if message.CycleID != 7 || len(message.Extra) != 1 || string(message.Extra["SeasonID"]) != `"S12"` {
+		t.Errorf("message = %+v", message)
+	}
+
+	out, err := json.Marshal(message)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(out) != data {
+		t.Errorf("Marshal() = %s, want %s", out, data)
+	}
+}
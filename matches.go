@@ -1,6 +1,9 @@
 package rlapi
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 type MatchEntry struct {
 	ReplayUrl string `json:"ReplayUrl"`
@@ -67,6 +70,8 @@ type GetMatchHistoryRequest struct {
 
 type GetMatchHistoryResponse struct {
 	Matches []MatchEntry `json:"Matches"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // GetMatchHistory retrieves match history for the authenticated player.
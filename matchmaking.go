@@ -1,6 +1,9 @@
 package rlapi
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 // MatchmakingSettings represents matchmaking configuration.
 type MatchmakingSettings struct {
@@ -34,6 +37,8 @@ type StartMatchmakingRequest struct {
 
 type StartMatchmakingResponse struct {
 	EstimatedQueueTime int `json:"EstimatedQueueTime"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type PlayerSearchPrivateMatchRequest struct {
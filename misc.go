@@ -1,6 +1,9 @@
 package rlapi
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 type TradeInFilter struct {
 	ID               int      `json:"ID"`
@@ -30,6 +33,8 @@ type GetSubRegionsRequest struct {
 
 type GetSubRegionsResponse struct {
 	Regions []Region `json:"Regions"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetGameServerPingListRequest struct {
@@ -38,10 +43,14 @@ type GetGameServerPingListRequest struct {
 
 type GetGameServerPingListResponse struct {
 	Servers []Server `json:"Servers"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetClubPrivateMatchesResponse struct {
 	Servers []Server `json:"Servers"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type JoinMatchRequest struct {
@@ -57,6 +66,8 @@ type FilterContentRequest struct {
 
 type FilterContentResponse struct {
 	FilteredContent []string `json:"FilteredContent"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type RecordMetricsRequest struct {
@@ -75,6 +86,8 @@ type RecordMetricsRequest struct {
 
 type GetTradeInFiltersResponse struct {
 	TradeInFilters []TradeInFilter `json:"TradeInFilters"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type RelayToServerRequest struct {
@@ -120,6 +133,8 @@ type CanShowAvatarRequest struct {
 type CanShowAvatarResponse struct {
 	AllowedPlayerIDs []PlayerID `json:"AllowedPlayerIDs"`
 	HiddenPlayerIDs  []PlayerID `json:"HiddenPlayerIDs"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // GetSubRegions retrieves available server regions.
@@ -158,14 +173,14 @@ func (p *PsyNetRPC) GetClubPrivateMatches(ctx context.Context) ([]Server, error)
 }
 
 // JoinMatch joins a private match.
-func (p *PsyNetRPC) JoinMatch(ctx context.Context, joinType, serverName, password string) (interface{}, error) {
+func (p *PsyNetRPC) JoinMatch(ctx context.Context, joinType, serverName, password string) (json.RawMessage, error) {
 	request := JoinMatchRequest{
 		JoinType:   joinType,
 		ServerName: serverName,
 		Password:   password,
 	}
 
-	var result interface{}
+	var result json.RawMessage
 	err := p.sendRequestSync(ctx, "Reservations/JoinMatch v1", request, &result)
 	if err != nil {
 		return nil, err
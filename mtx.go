@@ -1,6 +1,9 @@
 package rlapi
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 type MTXProduct struct {
 	ID                int           `json:"ID"`
@@ -33,6 +36,8 @@ type GetCatalogRequest struct {
 
 type GetCatalogResponse struct {
 	MTXProducts []MTXProduct `json:"MTXProducts"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type StartPurchaseRequest struct {
@@ -47,7 +52,9 @@ type ClaimEntitlementsRequest struct {
 }
 
 type ClaimEntitlementsResponse struct {
-	Products []interface{} `json:"Products"`
+	Products []json.RawMessage `json:"Products"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // GetMTXCatalog retrieves the DLC catalog (eg, starter packs).
@@ -81,7 +88,7 @@ func (p *PsyNetRPC) StartMTXPurchase(ctx context.Context, cartItems []MTXCartIte
 	return nil
 }
 
-func (p *PsyNetRPC) ClaimMTXEntitlements(ctx context.Context, authCode string) ([]interface{}, error) {
+func (p *PsyNetRPC) ClaimMTXEntitlements(ctx context.Context, authCode string) ([]json.RawMessage, error) {
 	request := ClaimEntitlementsRequest{
 		PlayerID: p.localPlayerID,
 		AuthCode: authCode,
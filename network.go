@@ -0,0 +1,104 @@
+package rlapi
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultDialTimeout      = 30 * time.Second
+	defaultHandshakeTimeout = 45 * time.Second
+)
+
+// errCertificateNotPinned is returned from the TLS handshake when the server certificate isn't pinned.
+var errCertificateNotPinned = errors.New("server certificate does not match any pinned certificate")
+
+// NetworkConfig configures how EGS and PsyNet connect, it is applied to HTTP requests and the WebSocket dial alike.
+type NetworkConfig struct {
+	// Proxy is an http, https or socks5 proxy URL. Nil falls back to the HTTP_PROXY/HTTPS_PROXY environment variables.
+	Proxy *url.URL
+	// RootCAs replaces the system root CAs used to verify servers.
+	RootCAs *x509.CertPool
+	// PinnedCertificates only accepts servers presenting one of these leaf certificates, replacing CA verification.
+	PinnedCertificates []*x509.Certificate
+	// LocalAddr is the source IP outgoing connections are bound to.
+	LocalAddr net.IP
+}
+
+func (c *NetworkConfig) proxy() func(*http.Request) (*url.URL, error) {
+	if c.Proxy != nil {
+		return http.ProxyURL(c.Proxy)
+	}
+	return http.ProxyFromEnvironment
+}
+
+func (c *NetworkConfig) netDialer() *net.Dialer {
+	dialer := &net.Dialer{
+		Timeout:   defaultDialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+	if c.LocalAddr != nil {
+		dialer.LocalAddr = &net.TCPAddr{IP: c.LocalAddr}
+	}
+	return dialer
+}
+
+func (c *NetworkConfig) tlsConfig() *tls.Config {
+	config := &tls.Config{RootCAs: c.RootCAs}
+	if len(c.PinnedCertificates) > 0 {
+		pinned := c.PinnedCertificates
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errCertificateNotPinned
+			}
+			for _, cert := range pinned {
+				if bytes.Equal(cert.Raw, rawCerts[0]) {
+					return nil
+				}
+			}
+			return errCertificateNotPinned
+		}
+	}
+	return config
+}
+
+// httpClient creates an HTTP client using the configuration.
+func (c *NetworkConfig) httpClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = c.proxy()
+	transport.DialContext = c.netDialer().DialContext
+	transport.TLSClientConfig = c.tlsConfig()
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+}
+
+// websocketDialer creates a WebSocket dialer using the configuration.
+func (c *NetworkConfig) websocketDialer() *websocket.Dialer {
+	return &websocket.Dialer{
+		Proxy:            c.proxy(),
+		NetDialContext:   c.netDialer().DialContext,
+		TLSClientConfig:  c.tlsConfig(),
+		HandshakeTimeout: defaultHandshakeTimeout,
+	}
+}
+
+// WithNetwork applies the network configuration to HTTP requests and the WebSocket dial,
+// replacing any client or dialer set by WithHTTPClient or WithDialer.
+func WithNetwork(config NetworkConfig) Option {
+	return func(p *PsyNet) {
+		p.client = config.httpClient(defaultHTTPTimeout)
+		p.dialer = config.websocketDialer()
+	}
+}
@@ -0,0 +1,113 @@
+package rlapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNetworkConfig_PinnedCertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Result":{}}`))
+	}))
+	defer server.Close()
+
+	var result struct{}
+
+	pinned := NewPsyNet(WithBaseURL(server.URL), WithNetwork(NetworkConfig{
+		PinnedCertificates: []*x509.Certificate{server.Certificate()},
+	}))
+	if err := pinned.postJSON(context.Background(), []string{"Test", "Call", "v1"}, map[string]string{}, &result); err != nil {
+		t.Errorf("pinned postJSON() error = %v", err)
+	}
+
+	mismatched := NewPsyNet(WithBaseURL(server.URL), WithNetwork(NetworkConfig{
+		PinnedCertificates: []*x509.Certificate{newTestCertificate(t)},
+	}))
+	err := mismatched.postJSON(context.Background(), []string{"Test", "Call", "v1"}, map[string]string{}, &result)
+	if !errors.Is(err, ErrTransport) || !strings.Contains(err.Error(), errCertificateNotPinned.Error()) {
+		t.Errorf("mismatched postJSON() error = %v, want pinning failure", err)
+	}
+}
+
+// newTestCertificate creates a self-signed certificate distinct from the one httptest servers present.
+func newTestCertificate(t *testing.T) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return cert
+}
+
+func TestNetworkConfig_WebSocketRootCAs(t *testing.T) {
+	mockServer := NewMockWSServer()
+	defer mockServer.Close()
+
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(mockServer.handleWebSocket))
+	defer tlsServer.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(tlsServer.Certificate())
+
+	psyNet := NewPsyNet(WithNetwork(NetworkConfig{RootCAs: roots}))
+	url := "wss" + strings.TrimPrefix(tlsServer.URL, "https")
+	rpc, err := psyNet.establishSocket(context.Background(), url, "test-player", "test-token", "test-session")
+	if err != nil {
+		t.Fatalf("Failed to establish socket: %v", err)
+	}
+	rpc.Close()
+
+	if _, err := NewPsyNet().establishSocket(context.Background(), url, "test-player", "test-token", "test-session"); err == nil {
+		t.Error("Expected dial without the custom root CA to fail")
+	}
+}
+
+func TestNetworkConfig_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte(`{"access_token":"test"}`))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	egs := NewEGS(WithEGSNetwork(NetworkConfig{Proxy: proxyURL}))
+
+	req, _ := http.NewRequest(http.MethodGet, "http://egs.invalid/token", nil)
+	resp, err := egs.client.Do(req)
+	if err != nil {
+		t.Fatalf("request through proxy failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if proxied != "http://egs.invalid/token" {
+		t.Errorf("proxied URL = %q, want http://egs.invalid/token", proxied)
+	}
+}
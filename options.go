@@ -0,0 +1,128 @@
+package rlapi
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultEnvironment = "Prod"
+	defaultHTTPTimeout = 30 * time.Second
+	// defaultRequestTimeout bounds WebSocket calls whose context has no deadline.
+	defaultRequestTimeout = 30 * time.Second
+)
+
+// Option configures a PsyNet client, see NewPsyNet.
+type Option func(*PsyNet)
+
+// WithBaseURL overrides the HTTP API base URL, e.g. to point at a local stand-in server.
+func WithBaseURL(url string) Option {
+	return func(p *PsyNet) {
+		p.baseURL = url
+	}
+}
+
+// WithWebSocketURL overrides the WebSocket URL returned by AuthPlayer.
+func WithWebSocketURL(url string) Option {
+	return func(p *PsyNet) {
+		p.wsURL = url
+	}
+}
+
+// WithEnvironment overrides the PsyEnvironment header, defaults to "Prod".
+func WithEnvironment(environment string) Option {
+	return func(p *PsyNet) {
+		p.environment = environment
+	}
+}
+
+// WithSigningKey overrides the key requests are signed with.
+func WithSigningKey(key string) Option {
+	return func(p *PsyNet) {
+		p.sigKey = key
+	}
+}
+
+// WithPingInterval overrides the interval between WebSocket pings, defaults to 20s.
+func WithPingInterval(interval time.Duration) Option {
+	return func(p *PsyNet) {
+		p.pingInterval = interval
+	}
+}
+
+// WithRequestTimeout overrides how long a WebSocket call waits for its response when its context has
+// no deadline, defaults to 30s. Calls with a deadline, shorter or longer, only follow their context.
+// Zero or less disables the timeout.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(p *PsyNet) {
+		p.requestTimeout = timeout
+	}
+}
+
+// WithWriteTimeout overrides the deadline of each WebSocket write, defaults to 10s.
+// A write that misses it drops the connection.
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(p *PsyNet) {
+		p.writeTimeout = timeout
+	}
+}
+
+// WithWriteQueue overrides how many outgoing requests may wait for the socket, defaults to 256.
+// Calls block while the queue is full.
+func WithWriteQueue(size int) Option {
+	return func(p *PsyNet) {
+		p.writeQueue = size
+	}
+}
+
+// WithPongTimeout overrides how long to wait for a pong before the connection is considered lost, defaults to 10s.
+func WithPongTimeout(timeout time.Duration) Option {
+	return func(p *PsyNet) {
+		p.pongTimeout = timeout
+	}
+}
+
+// WithUserAgent overrides the User-Agent sent with HTTP requests, by default it is derived from the game version.
+func WithUserAgent(userAgent string) Option {
+	return func(p *PsyNet) {
+		p.userAgent = userAgent
+	}
+}
+
+// WithWebSocketUserAgent overrides the User-Agent sent with the WebSocket handshake, by default it is derived from the game version.
+func WithWebSocketUserAgent(userAgent string) Option {
+	return func(p *PsyNet) {
+		p.wsUserAgent = userAgent
+	}
+}
+
+// WithEventBuffer overrides the buffer size of the channel returned by PsyNetRPC.Events, defaults to 32.
+func WithEventBuffer(size int) Option {
+	return func(p *PsyNet) {
+		p.eventBuffer = size
+	}
+}
+
+// WithHTTPClient overrides the HTTP client, by default a client with a 30s timeout is used.
+func WithHTTPClient(client *http.Client) Option {
+	return func(p *PsyNet) {
+		p.client = client
+	}
+}
+
+// WithDialer overrides the WebSocket dialer.
+func WithDialer(dialer *websocket.Dialer) Option {
+	return func(p *PsyNet) {
+		p.dialer = dialer
+	}
+}
+
+// WithLogger overrides the logger, defaults to slog.Default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(p *PsyNet) {
+		p.logger = logger
+	}
+}
@@ -0,0 +1,67 @@
+package rlapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewPsyNet_Options(t *testing.T) {
+	var got *http.Request
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.Write([]byte(`{"Result":{}}`))
+	}))
+	defer server.Close()
+
+	psyNet := NewPsyNet(
+		WithBaseURL(server.URL+"/rpc"),
+		WithEnvironment("Staging"),
+		WithUserAgent("test-agent"),
+		WithSigningKey("test-key"),
+		WithHTTPClient(server.Client()),
+	)
+
+	var result struct{}
+	if err := psyNet.postJSON(context.Background(), []string{"Test", "Call", "v1"}, map[string]string{}, &result); err != nil {
+		t.Fatalf("postJSON() error = %v", err)
+	}
+
+	if got.URL.Path != "/rpc/Test/Call/v1" {
+		t.Errorf("path = %s, want /rpc/Test/Call/v1", got.URL.Path)
+	}
+	if env := got.Header.Get("PsyEnvironment"); env != "Staging" {
+		t.Errorf("PsyEnvironment = %s, want Staging", env)
+	}
+	if ua := got.Header.Get("User-Agent"); ua != "test-agent" {
+		t.Errorf("User-Agent = %s, want test-agent", ua)
+	}
+	if sig := got.Header.Get("PsySig"); sig != generatePsySig("test-key", gotBody) {
+		t.Errorf("PsySig = %s, want signature with test-key", sig)
+	}
+}
+
+func TestNewPsyNet_ConnectionOptions(t *testing.T) {
+	psyNet := NewPsyNet(
+		WithPingInterval(time.Second),
+		WithPongTimeout(500*time.Millisecond),
+		WithEventBuffer(4),
+		WithWebSocketURL("ws://localhost:1234"),
+	)
+
+	rpc := psyNet.newHTTPRPC("test-player", "test-token", "test-session")
+	if rpc.pingInterval != time.Second || rpc.pongTimeout != 500*time.Millisecond {
+		t.Errorf("ping interval/pong timeout = %v/%v, want 1s/500ms", rpc.pingInterval, rpc.pongTimeout)
+	}
+	if cap(rpc.Events()) != 4 {
+		t.Errorf("event buffer = %d, want 4", cap(rpc.Events()))
+	}
+	if url := psyNet.socketURL("wss://example.com"); url != "ws://localhost:1234" {
+		t.Errorf("socketURL() = %s, want ws://localhost:1234", url)
+	}
+}
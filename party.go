@@ -1,6 +1,9 @@
 package rlapi
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 type PartyID string
 
@@ -22,10 +25,14 @@ type PartyMember struct {
 type PartyResponse struct {
 	Info    PartyInfo     `json:"Info"`
 	Members []PartyMember `json:"Members"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetPlayerPartyInfoResponse struct {
-	Invites []interface{} `json:"Invites"`
+	Invites []json.RawMessage `json:"Invites"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type CreatePartyRequest struct {
@@ -69,6 +76,8 @@ type SendPartyChatMessageRequest struct {
 type SendPartyChatMessageResponse struct {
 	Success   bool   `json:"Success"`
 	MessageID string `json:"MessageID"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type SendPartyMessageRequest struct {
@@ -79,10 +88,12 @@ type SendPartyMessageRequest struct {
 type SendPartyMessageResponse struct {
 	Success   bool   `json:"Success"`
 	MessageID string `json:"MessageID"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // GetPlayerPartyInfo pending party invitations for the authenticated player.
-func (p *PsyNetRPC) GetPlayerPartyInfo(ctx context.Context) ([]interface{}, error) {
+func (p *PsyNetRPC) GetPlayerPartyInfo(ctx context.Context) ([]json.RawMessage, error) {
 	var result GetPlayerPartyInfoResponse
 	err := p.sendRequestSync(ctx, "Party/GetPlayerPartyInfo v1", emptyRequest{}, &result)
 	if err != nil {
@@ -1,6 +1,9 @@
 package rlapi
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 type PlayerData struct {
 	PlayerID      string `json:"PlayerID"`
@@ -35,7 +38,9 @@ type GetBanStatusRequest struct {
 }
 
 type GetBanStatusResponse struct {
-	BanMessages []interface{} `json:"BanMessages"`
+	BanMessages []json.RawMessage `json:"BanMessages"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetProfileRequest struct {
@@ -44,6 +49,8 @@ type GetProfileRequest struct {
 
 type GetProfileResponse struct {
 	PlayerData []PlayerData `json:"PlayerData"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetXPRequest struct {
@@ -52,6 +59,8 @@ type GetXPRequest struct {
 
 type GetXPResponse struct {
 	XPInfoResponse PlayerXPInfo `json:"XPInfoResponse"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetCreatorCodeRequest struct {
@@ -59,7 +68,9 @@ type GetCreatorCodeRequest struct {
 }
 
 type GetCreatorCodeResponse struct {
-	CreatorCode interface{} `json:"CreatorCode"`
+	CreatorCode json.RawMessage `json:"CreatorCode"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type ReportRequest struct {
@@ -78,10 +89,12 @@ type ReportResponse struct {
 	Success  bool   `json:"Success"`
 	ReportID string `json:"ReportID"`
 	Message  string `json:"Message"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // GetBanStatus retrieves ban status information for given players.
-func (p *PsyNetRPC) GetBanStatus(ctx context.Context, playerIDs []PlayerID) ([]interface{}, error) {
+func (p *PsyNetRPC) GetBanStatus(ctx context.Context, playerIDs []PlayerID) ([]json.RawMessage, error) {
 	request := GetBanStatusRequest{
 		Players: playerIDs,
 	}
@@ -123,13 +136,13 @@ func (p *PsyNetRPC) GetXP(ctx context.Context) (*PlayerXPInfo, error) {
 }
 
 // GetCreatorCode retrieves creator code information for the authenticated player.
-func (p *PsyNetRPC) GetCreatorCode(ctx context.Context) (interface{}, error) {
+func (p *PsyNetRPC) GetCreatorCode(ctx context.Context) (json.RawMessage, error) {
 	var result GetCreatorCodeResponse
 	err := p.sendRequestSync(ctx, "Players/GetCreatorCode v1", emptyRequest{}, &result)
 	if err != nil {
 		return nil, err
 	}
-	return &result.CreatorCode, nil
+	return result.CreatorCode, nil
 }
 
 // ReportPlayer reports a player.
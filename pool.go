@@ -0,0 +1,421 @@
+package rlapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPoolAttempts        = 2
+	defaultPoolQuarantineAfter = 3
+	defaultPoolQuarantineFor   = 5 * time.Minute
+	defaultPoolHealthInterval  = 30 * time.Second
+	defaultPoolBanCooldown     = time.Hour
+	poolDrainTimeout           = 30 * time.Second
+)
+
+var (
+	// ErrNoAvailableAccounts is returned when every pool account is quarantined, benched or reconnecting.
+	ErrNoAvailableAccounts = errors.New("no pool accounts available")
+	// ErrNotReadOnly is returned by Pool.Call for services that modify state.
+	ErrNotReadOnly = errors.New("service is not read-only")
+)
+
+// PoolStrategy decides which account serves the next call.
+type PoolStrategy int
+
+const (
+	// PoolLeastLoaded picks the account with the fewest in-flight calls.
+	PoolLeastLoaded PoolStrategy = iota
+	// PoolRoundRobin cycles through the accounts in order.
+	PoolRoundRobin
+)
+
+// ConnectFunc authenticates an account and returns its connection, it is called again to re-authenticate.
+type ConnectFunc func(ctx context.Context) (*PsyNetRPC, error)
+
+// PoolOptions configures a pool, zero values fall back to defaults.
+type PoolOptions struct {
+	// Strategy selects accounts for calls. Defaults to PoolLeastLoaded.
+	Strategy PoolStrategy
+	// MaxAttempts is the number of accounts a failed call is tried on. Defaults to 2.
+	MaxAttempts int
+	// QuarantineAfter is the number of consecutive failures before an account is quarantined. Defaults to 3.
+	QuarantineAfter int
+	// QuarantineFor is how long a failing account is kept out of rotation before it is re-authenticated. Defaults to 5m.
+	QuarantineFor time.Duration
+	// HealthInterval is the interval between health checks in Run. Defaults to 30s.
+	HealthInterval time.Duration
+	// BanCooldown is how long an account that failed with ErrBanned is benched before it is re-authenticated. Defaults to 1h.
+	// ErrBanned only matches error types registered with RegisterErrorType.
+	BanCooldown time.Duration
+}
+
+// PoolAccountStats is a snapshot of a pool account's metrics.
+type PoolAccountStats struct {
+	Name              string
+	Connected         bool
+	InFlight          int
+	Calls             uint64
+	Errors            uint64
+	ConsecutiveErrors int
+	LastError         error
+	MeanLatency       time.Duration
+	Reauths           int
+	BannedUntil       time.Time
+	QuarantinedUntil  time.Time
+}
+
+type poolMember struct {
+	name    string
+	connect ConnectFunc
+	rpc     *PsyNetRPC
+
+	inFlight         int
+	calls            uint64
+	errors           uint64
+	consecutive      int
+	lastErr          error
+	latency          time.Duration
+	reauths          int
+	bannedUntil      time.Time
+	needsReauth      bool
+	connecting       bool
+	quarantinedUntil time.Time
+}
+
+// Pool spreads read-only calls across several authenticated accounts, see Pool.Run for health checks.
+type Pool struct {
+	opts   PoolOptions
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	members []*poolMember
+	next    int
+}
+
+// NewPool creates an empty pool, add accounts with Pool.Add.
+func NewPool(opts PoolOptions) *Pool {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultPoolAttempts
+	}
+	if opts.QuarantineAfter <= 0 {
+		opts.QuarantineAfter = defaultPoolQuarantineAfter
+	}
+	if opts.QuarantineFor <= 0 {
+		opts.QuarantineFor = defaultPoolQuarantineFor
+	}
+	if opts.HealthInterval <= 0 {
+		opts.HealthInterval = defaultPoolHealthInterval
+	}
+	if opts.BanCooldown <= 0 {
+		opts.BanCooldown = defaultPoolBanCooldown
+	}
+
+	return &Pool{
+		opts:   opts,
+		logger: slog.Default(),
+	}
+}
+
+func (p *Pool) SetLogger(logger *slog.Logger) {
+	p.logger = logger
+}
+
+// Add connects an account and adds it to the pool, connect is called again whenever the account must re-authenticate.
+func (p *Pool) Add(ctx context.Context, name string, connect ConnectFunc) error {
+	rpc, err := connect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect pool account %s: %w", name, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.members = append(p.members, &poolMember{
+		name:    name,
+		connect: connect,
+		rpc:     rpc,
+	})
+
+	return nil
+}
+
+// Call sends a read-only request through one of the pool's accounts, see PsyNetRPC.Call.
+// Calls failing with a retryable or authentication error are tried again on another account.
+func (p *Pool) Call(ctx context.Context, service string, version int, request interface{}, response interface{}) error {
+	if !IsIdempotentService(service) {
+		return fmt.Errorf("%w: %s", ErrNotReadOnly, service)
+	}
+
+	return p.Do(ctx, func(rpc *PsyNetRPC) error {
+		return rpc.Call(ctx, service, version, request, response)
+	})
+}
+
+// Do runs fn with a connection from the pool, fn must only make read-only calls since it may run on more than one account.
+func (p *Pool) Do(ctx context.Context, fn func(rpc *PsyNetRPC) error) error {
+	tried := make(map[*poolMember]bool, p.opts.MaxAttempts)
+
+	var err error
+	for range p.opts.MaxAttempts {
+		member, rpc := p.acquire(tried)
+		if member == nil {
+			break
+		}
+		tried[member] = true
+
+		start := time.Now()
+		err = fn(rpc)
+		p.release(member, err, time.Since(start))
+
+		if err == nil || ctx.Err() != nil || !p.failover(err) {
+			return err
+		}
+		p.logger.Debug("pool call failed, trying another account", slog.String("account", member.name), slog.Any("err", err))
+	}
+
+	if err == nil {
+		return ErrNoAvailableAccounts
+	}
+	return err
+}
+
+// poolLookup adapts a multi-player lookup to run each chunk of a batch on a pool account, with failover per chunk.
+func poolLookup[T any](p *Pool, lookup func(rpc *PsyNetRPC, ctx context.Context, chunk []PlayerID) (T, error)) func(context.Context, []PlayerID) (T, error) {
+	return func(ctx context.Context, chunk []PlayerID) (T, error) {
+		var result T
+		err := p.Do(ctx, func(rpc *PsyNetRPC) error {
+			var err error
+			result, err = lookup(rpc, ctx, chunk)
+			return err
+		})
+		return result, err
+	}
+}
+
+// GetProfiles looks up any number of players as if from one client, chunks are spread across the pool's accounts.
+// On a *BatchError the profiles of the chunks that succeeded are still returned, in input order.
+func (p *Pool) GetProfiles(ctx context.Context, playerIDs []PlayerID, opts BatchOptions) ([]PlayerData, error) {
+	results, _, err := runBatch(ctx, playerIDs, opts, poolLookup(p, (*PsyNetRPC).GetProfiles))
+	return concatBatch(results, err)
+}
+
+// GetPlayersSkills is like GetProfiles for player skills.
+func (p *Pool) GetPlayersSkills(ctx context.Context, playerIDs []PlayerID, opts BatchOptions) ([]PlayerWithSkills, error) {
+	results, _, err := runBatch(ctx, playerIDs, opts, poolLookup(p, (*PsyNetRPC).GetPlayersSkills))
+	return concatBatch(results, err)
+}
+
+// GetBanStatus is like GetProfiles for ban status.
+func (p *Pool) GetBanStatus(ctx context.Context, playerIDs []PlayerID, opts BatchOptions) ([]json.RawMessage, error) {
+	results, _, err := runBatch(ctx, playerIDs, opts, poolLookup(p, (*PsyNetRPC).GetBanStatus))
+	return concatBatch(results, err)
+}
+
+// CanShowAvatar is like GetProfiles for avatar visibility, the allowed and hidden players of every chunk are merged.
+func (p *Pool) CanShowAvatar(ctx context.Context, playerIDs []PlayerID, opts BatchOptions) (*CanShowAvatarResponse, error) {
+	results, ok, err := runBatch(ctx, playerIDs, opts, poolLookup(p, (*PsyNetRPC).CanShowAvatar))
+	return mergeCanShowAvatar(results, ok), err
+}
+
+// failover reports whether a call that failed with err may succeed on another account.
+// Unlike retries on the same connection, any transport failure counts since the next account has its own socket.
+func (p *Pool) failover(err error) bool {
+	return IsRetryable(err) || errors.Is(err, ErrTransport) || errors.Is(err, ErrAuthExpired) || errors.Is(err, ErrBanned)
+}
+
+// acquire selects an available account and marks a call in flight on it.
+func (p *Pool) acquire(skip map[*poolMember]bool) (*poolMember, *PsyNetRPC) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var selected *poolMember
+	for i := range p.members {
+		idx := (p.next + i) % len(p.members)
+		member := p.members[idx]
+		if skip[member] || !member.available(now) {
+			continue
+		}
+		if p.opts.Strategy == PoolRoundRobin {
+			selected = member
+			p.next = idx + 1
+			break
+		}
+		if selected == nil || member.inFlight < selected.inFlight {
+			selected = member
+		}
+	}
+
+	if selected == nil {
+		return nil, nil
+	}
+	if p.opts.Strategy == PoolLeastLoaded {
+		p.next++
+	}
+	selected.inFlight++
+	return selected, selected.rpc
+}
+
+func (m *poolMember) available(now time.Time) bool {
+	return !m.needsReauth && !m.connecting && now.After(m.bannedUntil) && now.After(m.quarantinedUntil) && m.rpc.IsConnected()
+}
+
+// release records the outcome of a call, quarantining the account if it keeps failing.
+func (p *Pool) release(member *poolMember, err error, latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	member.inFlight--
+	member.calls++
+	member.latency += latency
+
+	if err == nil {
+		member.consecutive = 0
+		return
+	}
+	if !p.failover(err) {
+		// validation and service errors are the caller's, not the account's
+		return
+	}
+
+	member.errors++
+	member.consecutive++
+	member.lastErr = err
+
+	switch {
+	case errors.Is(err, ErrBanned):
+		p.bench(member)
+		p.logger.Warn("pool account benched", slog.String("account", member.name), slog.Any("err", err))
+	case errors.Is(err, ErrAuthExpired):
+		member.needsReauth = true
+	case member.consecutive >= p.opts.QuarantineAfter:
+		member.quarantinedUntil = time.Now().Add(p.opts.QuarantineFor)
+		member.needsReauth = true
+		p.logger.Warn("pool account quarantined", slog.String("account", member.name), slog.Any("err", err))
+	}
+}
+
+// bench keeps a banned account out of rotation for the ban cooldown, it re-authenticates afterwards, p.mu must be held.
+func (p *Pool) bench(member *poolMember) {
+	member.bannedUntil = time.Now().Add(p.opts.BanCooldown)
+	member.needsReauth = true
+}
+
+// Run health-checks the pool until ctx is done, re-authenticating accounts that lost their connection,
+// whose authentication expired or whose quarantine or ban cooldown ended.
+func (p *Pool) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.opts.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		p.healthCheck(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (p *Pool) healthCheck(ctx context.Context) {
+	now := time.Now()
+
+	p.mu.Lock()
+	var stale []*poolMember
+	for _, member := range p.members {
+		if member.connecting || now.Before(member.bannedUntil) || now.Before(member.quarantinedUntil) {
+			continue
+		}
+		if member.needsReauth || !member.rpc.IsConnected() {
+			member.connecting = true
+			stale = append(stale, member)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, member := range stale {
+		p.reauth(ctx, member)
+	}
+}
+
+func (p *Pool) reauth(ctx context.Context, member *poolMember) {
+	p.logger.Debug("re-authenticating pool account", slog.String("account", member.name))
+	rpc, err := member.connect(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	member.connecting = false
+	if err != nil {
+		member.lastErr = err
+		member.quarantinedUntil = time.Now().Add(p.opts.QuarantineFor)
+		if errors.Is(err, ErrBanned) {
+			p.bench(member)
+		}
+		p.logger.Warn("failed to re-authenticate pool account", slog.String("account", member.name), slog.Any("err", err))
+		return
+	}
+
+	old := member.rpc
+	member.rpc = rpc
+	member.reauths++
+	member.needsReauth = false
+	member.consecutive = 0
+	member.bannedUntil = time.Time{}
+	member.quarantinedUntil = time.Time{}
+
+	// calls still running on the old connection hold their own reference
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), poolDrainTimeout)
+		defer cancel()
+		old.Shutdown(ctx)
+	}()
+}
+
+// Stats returns per-account metrics in the order accounts were added.
+func (p *Pool) Stats() []PoolAccountStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make([]PoolAccountStats, 0, len(p.members))
+	for _, member := range p.members {
+		s := PoolAccountStats{
+			Name:              member.name,
+			Connected:         member.rpc.IsConnected(),
+			InFlight:          member.inFlight,
+			Calls:             member.calls,
+			Errors:            member.errors,
+			ConsecutiveErrors: member.consecutive,
+			LastError:         member.lastErr,
+			Reauths:           member.reauths,
+			BannedUntil:       member.bannedUntil,
+			QuarantinedUntil:  member.quarantinedUntil,
+		}
+		if member.calls > 0 {
+			s.MeanLatency = member.latency / time.Duration(member.calls)
+		}
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+// Close closes every account's connection.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+	for _, member := range p.members {
+		if err := member.rpc.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close pool account %s: %w", member.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,214 @@
+package rlapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestPool(t *testing.T, opts PoolOptions, names ...string) *Pool {
+	pool := NewPool(opts)
+	for _, name := range names {
+		connect := func(ctx context.Context) (*PsyNetRPC, error) {
+			return NewPsyNet().newHTTPRPC(PlayerID(name), "test-token", "test-session"), nil
+		}
+		if err := pool.Add(context.Background(), name, connect); err != nil {
+			t.Fatalf("Add(%s) error = %v", name, err)
+		}
+	}
+	return pool
+}
+
+func TestPool_RoundRobin(t *testing.T) {
+	pool := newTestPool(t, PoolOptions{Strategy: PoolRoundRobin}, "a", "b", "c")
+	defer pool.Close()
+
+	var got []PlayerID
+	for range 6 {
+		pool.Do(context.Background(), func(rpc *PsyNetRPC) error {
+			got = append(got, rpc.localPlayerID)
+			return nil
+		})
+	}
+
+	want := []PlayerID{"a", "b", "c", "a", "b", "c"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("accounts = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPool_FailoverAndBan(t *testing.T) {
+	pool := newTestPool(t, PoolOptions{Strategy: PoolRoundRobin, BanCooldown: 50 * time.Millisecond}, "a", "b")
+	defer pool.Close()
+
+	var calls []PlayerID
+	err := pool.Do(context.Background(), func(rpc *PsyNetRPC) error {
+		calls = append(calls, rpc.localPlayerID)
+		if rpc.localPlayerID == "a" {
+			return fmt.Errorf("account banned: %w", ErrBanned)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if len(calls) != 2 || calls[1] != "b" {
+		t.Errorf("calls = %v, want failover from a to b", calls)
+	}
+
+	stats := pool.Stats()
+	if stats[0].BannedUntil.IsZero() || stats[0].Errors != 1 {
+		t.Errorf("stats[a] = %+v, want benched with one error", stats[0])
+	}
+	if stats[1].Calls != 1 || stats[1].Errors != 0 {
+		t.Errorf("stats[b] = %+v, want one successful call", stats[1])
+	}
+
+	// a stays out of rotation until the cooldown ends and it re-authenticates
+	for range 3 {
+		pool.Do(context.Background(), func(rpc *PsyNetRPC) error {
+			if rpc.localPlayerID == "a" {
+				t.Error("benched account selected")
+			}
+			return nil
+		})
+	}
+	pool.healthCheck(context.Background())
+	if stats := pool.Stats(); stats[0].Reauths != 0 {
+		t.Error("Expected benched account not to re-authenticate during the cooldown")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	pool.healthCheck(context.Background())
+
+	var selected []PlayerID
+	for range 2 {
+		pool.Do(context.Background(), func(rpc *PsyNetRPC) error {
+			selected = append(selected, rpc.localPlayerID)
+			return nil
+		})
+	}
+	if !slices.Contains(selected, "a") {
+		t.Errorf("selected = %v, want a back in rotation after the cooldown", selected)
+	}
+}
+
+func TestPool_UnconfirmedBanType(t *testing.T) {
+	pool := newTestPool(t, PoolOptions{MaxAttempts: 1}, "a")
+	defer pool.Close()
+
+	// error types that merely look like bans don't bench the account
+	pool.Do(context.Background(), func(rpc *PsyNetRPC) error {
+		return &PsyNetError{Type: "PlayerBanned"}
+	})
+	if err := pool.Do(context.Background(), func(rpc *PsyNetRPC) error { return nil }); err != nil {
+		t.Errorf("Do() error = %v, want the account still available", err)
+	}
+}
+
+func TestPool_GetProfiles(t *testing.T) {
+	pool := NewPool(PoolOptions{})
+	defer pool.Close()
+
+	var mu sync.Mutex
+	served := make(map[PlayerID]int)
+	for _, name := range []PlayerID{"a", "b"} {
+		connect := func(ctx context.Context) (*PsyNetRPC, error) {
+			rpc := NewPsyNet().newHTTPRPC(name, "test-token", "test-session")
+			rpc.Use(func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+				mu.Lock()
+				served[name]++
+				mu.Unlock()
+				time.Sleep(5 * time.Millisecond)
+
+				response := result.(*GetProfileResponse)
+				for _, id := range info.Request.(GetProfileRequest).PlayerIDs {
+					response.PlayerData = append(response.PlayerData, PlayerData{PlayerID: string(id)})
+				}
+				return nil
+			})
+			return rpc, nil
+		}
+		if err := pool.Add(context.Background(), string(name), connect); err != nil {
+			t.Fatalf("Add(%s) error = %v", name, err)
+		}
+	}
+
+	var ids []PlayerID
+	for i := range 8 {
+		ids = append(ids, PlayerID(fmt.Sprintf("Epic|%d|0", i)))
+	}
+
+	profiles, err := pool.GetProfiles(context.Background(), ids, BatchOptions{ChunkSize: 2, Concurrency: 4})
+	if err != nil {
+		t.Fatalf("GetProfiles() error = %v", err)
+	}
+	if len(profiles) != len(ids) {
+		t.Fatalf("profiles = %d, want %d", len(profiles), len(ids))
+	}
+	for i, profile := range profiles {
+		if profile.PlayerID != string(ids[i]) {
+			t.Errorf("profiles[%d] = %s, want %s", i, profile.PlayerID, ids[i])
+		}
+	}
+	if served["a"] == 0 || served["b"] == 0 {
+		t.Errorf("served = %v, want chunks spread across both accounts", served)
+	}
+}
+
+func TestPool_Quarantine(t *testing.T) {
+	pool := newTestPool(t, PoolOptions{QuarantineAfter: 2, MaxAttempts: 1}, "a")
+	defer pool.Close()
+
+	for range 2 {
+		pool.Do(context.Background(), func(rpc *PsyNetRPC) error {
+			return ErrConnectionClosed
+		})
+	}
+
+	err := pool.Do(context.Background(), func(rpc *PsyNetRPC) error { return nil })
+	if !errors.Is(err, ErrNoAvailableAccounts) {
+		t.Errorf("Do() error = %v, want ErrNoAvailableAccounts", err)
+	}
+	if stats := pool.Stats(); stats[0].QuarantinedUntil.IsZero() {
+		t.Error("Expected account to be quarantined")
+	}
+}
+
+func TestPool_Reauth(t *testing.T) {
+	pool := newTestPool(t, PoolOptions{}, "a")
+	defer pool.Close()
+
+	pool.Do(context.Background(), func(rpc *PsyNetRPC) error {
+		return &HTTPError{StatusCode: http.StatusUnauthorized, Status: "401 Unauthorized"}
+	})
+	if err := pool.Do(context.Background(), func(rpc *PsyNetRPC) error { return nil }); !errors.Is(err, ErrNoAvailableAccounts) {
+		t.Errorf("Do() error = %v, want ErrNoAvailableAccounts before re-auth", err)
+	}
+
+	pool.healthCheck(context.Background())
+
+	if stats := pool.Stats(); stats[0].Reauths != 1 {
+		t.Errorf("Reauths = %d, want 1", stats[0].Reauths)
+	}
+	if err := pool.Do(context.Background(), func(rpc *PsyNetRPC) error { return nil }); err != nil {
+		t.Errorf("Do() error = %v after re-auth", err)
+	}
+}
+
+func TestPool_CallRejectsWrites(t *testing.T) {
+	pool := newTestPool(t, PoolOptions{}, "a")
+	defer pool.Close()
+
+	err := pool.Call(context.Background(), "Products/TradeIn", 2, nil, nil)
+	if !errors.Is(err, ErrNotReadOnly) {
+		t.Errorf("Call() error = %v, want ErrNotReadOnly", err)
+	}
+}
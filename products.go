@@ -2,6 +2,7 @@ package rlapi
 
 import (
 	"context"
+	"encoding/json"
 	"strconv"
 )
 
@@ -37,10 +38,14 @@ type GetPlayerProductsRequest struct {
 
 type GetPlayerProductsResponse struct {
 	ProductData []Product `json:"ProductData"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetContainerDropTableResponse struct {
 	ContainerDrops []ContainerDrop `json:"ContainerDrops"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type UnlockContainerRequest struct {
@@ -51,6 +56,8 @@ type UnlockContainerRequest struct {
 
 type UnlockContainerResponse struct {
 	Drops []Product `json:"Drops"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type TradeInRequest struct {
@@ -60,11 +67,15 @@ type TradeInRequest struct {
 
 type TradeInResponse struct {
 	Drops []Product `json:"Drops"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetProductStatusResponse struct {
-	CrossEntitledProductIDs []int         `json:"CrossEntitledProductIDs"`
-	LockedProductIDs        []interface{} `json:"LockedProductIDs"`
+	CrossEntitledProductIDs []int             `json:"CrossEntitledProductIDs"`
+	LockedProductIDs        []json.RawMessage `json:"LockedProductIDs"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // GetPlayerProducts retrieves all products/items owned by the authenticated player.
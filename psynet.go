@@ -2,6 +2,7 @@ package rlapi
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
@@ -26,15 +27,6 @@ const (
 	pongTimeout  = 10 * time.Second
 )
 
-type psyNetError struct {
-	Type    string `json:"Type"`
-	Message string `json:"Message"`
-}
-
-func (e psyNetError) Error() string {
-	return fmt.Sprintf("%s: %s", e.Type, e.Message)
-}
-
 // PsyNet represents the HTTP API client, see PsyNetRPC for the WebSocket client.
 type PsyNet struct {
 	client      *http.Client
@@ -43,6 +35,25 @@ type PsyNet struct {
 	gameVersion string
 	featureSet  string
 	buildID     string
+
+	baseURL      string
+	wsURL        string
+	environment  string
+	sigKey       string
+	userAgent    string
+	wsUserAgent  string
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+	eventBuffer  int
+	dialer       *websocket.Dialer
+
+	requestTimeout time.Duration
+	writeTimeout   time.Duration
+	writeQueue     int
+
+	transportMode  TransportMode
+	interceptors   []Interceptor
+	responseSigKey string
 }
 
 type PsyRequest struct {
@@ -52,40 +63,60 @@ type PsyRequest struct {
 	Data      interface{} `json:"-"`
 }
 
+// PsyResponse is the envelope of a response. Responses routed over the WebSocket keep
+// their raw body and are decoded straight into the caller's result, see decodeResponse.
 type PsyResponse struct {
-	ResponseID string          `json:"PsyResponseID"`
-	Result     json.RawMessage `json:"Result"`
-	Error      *psyNetError    `json:"Error"`
+	ResponseID string `json:"PsyResponseID"`
+	// Deprecated: Result is only marshalled when building a response, e.g. in a test server.
+	// It isn't populated on received responses, the body is decoded straight into the result
+	// passed to the service methods or PendingCall.Wait.
+	Result json.RawMessage `json:"Result"`
+	// Deprecated: Error is only marshalled when building a response, received errors are
+	// returned by the service methods and PendingCall.Wait as a *PsyNetError.
+	Error *PsyNetError `json:"Error"`
+
+	service string
+	body    []byte
+	err     error
 }
 
-func generatePsySig(body []byte) string {
-	h := hmac.New(sha256.New, []byte(psySigKey))
+func generatePsySig(key string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(key))
 	h.Write([]byte("-"))
 	h.Write(body)
 	return base64.StdEncoding.EncodeToString(h.Sum(nil))
 }
 
-func NewPsyNet() *PsyNet {
-	return &PsyNet{
-		client:      &http.Client{},
-		requestID:   &requestIDCounter{},
-		logger:      slog.Default(),
-		gameVersion: gameVersion,
-		featureSet:  featureSet,
-		buildID:     strconv.Itoa(int(decodeBuildID(gameVersion))),
+// NewPsyNet creates a PsyNet client, options override the production defaults.
+func NewPsyNet(opts ...Option) *PsyNet {
+	p := &PsyNet{
+		client:       &http.Client{Timeout: defaultHTTPTimeout},
+		requestID:    &requestIDCounter{},
+		logger:       slog.Default(),
+		gameVersion:  gameVersion,
+		featureSet:   featureSet,
+		buildID:      strconv.Itoa(int(decodeBuildID(gameVersion))),
+		baseURL:      baseURL,
+		environment:  defaultEnvironment,
+		sigKey:       psySigKey,
+		pingInterval: pingInterval,
+		pongTimeout:  pongTimeout,
+		eventBuffer:  defaultSubscriptionBuffer,
+		dialer:       &websocket.Dialer{},
+
+		requestTimeout: defaultRequestTimeout,
+		writeTimeout:   defaultWriteTimeout,
+		writeQueue:     defaultWriteQueue,
+	}
+	for _, opt := range opts {
+		opt(p)
 	}
+	return p
 }
 
 // Deprecated: Use NewPsyNet and SetLogger instead.
 func NewPsyNetWithLogger(logger *slog.Logger) *PsyNet {
-	return &PsyNet{
-		client:      &http.Client{},
-		requestID:   &requestIDCounter{},
-		logger:      logger,
-		gameVersion: gameVersion,
-		featureSet:  featureSet,
-		buildID:     strconv.Itoa(int(decodeBuildID(gameVersion))),
-	}
+	return NewPsyNet(WithLogger(logger))
 }
 
 func (p *PsyNet) SetLogger(logger *slog.Logger) {
@@ -104,26 +135,77 @@ func (p *PsyNet) GetVersion() (gameVersion, featureSet string) {
 	return p.gameVersion, p.featureSet
 }
 
-func (p *PsyNet) establishSocket(url string, playerID PlayerID, psyToken string, sessionID string) (*PsyNetRPC, error) {
+func (p *PsyNet) establishSocket(ctx context.Context, url string, playerID PlayerID, psyToken string, sessionID string) (*PsyNetRPC, error) {
+	conn, _, err := p.dialSocket(ctx, url, psyToken, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	rpc := newPsyNetRPC(conn, playerID, p)
+	rpc.url = url
+	rpc.psyToken = psyToken
+	rpc.sessionID = sessionID
+
+	return rpc, nil
+}
+
+// socketURL returns the WebSocket URL to dial, the configured override wins over the one returned by AuthPlayer.
+func (p *PsyNet) socketURL(url string) string {
+	if p.wsURL != "" {
+		return p.wsURL
+	}
+	return url
+}
+
+// dialSocket opens the WebSocket connection, the handshake response is returned so callers can inspect rejected tokens.
+func (p *PsyNet) dialSocket(ctx context.Context, url string, psyToken string, sessionID string) (*websocket.Conn, *http.Response, error) {
 	p.logger.Debug("establishing websocket connection", slog.String("url", url))
 
-	dialer := websocket.Dialer{}
-	conn, _, err := dialer.Dial(url, http.Header{
+	userAgent := p.wsUserAgent
+	if userAgent == "" {
+		userAgent = fmt.Sprintf("RL Win/%s gzip", p.gameVersion)
+	}
+
+	conn, resp, err := p.dialer.DialContext(ctx, url, http.Header{
 		"PsyBuildID":     []string{p.buildID},
-		"User-Agent":     []string{fmt.Sprintf("RL Win/%s gzip", p.gameVersion)},
-		"PsyEnvironment": []string{"Prod"},
+		"User-Agent":     []string{userAgent},
+		"PsyEnvironment": []string{p.environment},
 		"PsyToken":       []string{psyToken},
 		"PsySessionID":   []string{sessionID},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to dial websocket: %w", err)
+		// the dialer reports an interrupted handshake as an i/o error, and its connection
+		// deadline may fire just before the context's own timer
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		} else if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+			err = context.DeadlineExceeded
+		}
+		return nil, resp, fmt.Errorf("failed to dial websocket: %w", err)
+	}
+
+	return conn, resp, nil
+}
+
+func (p *PsyNet) postJSON(ctx context.Context, path []string, params interface{}, result interface{}) error {
+	info := &CallInfo{
+		Service:   strings.Join(path, "/"),
+		RequestID: p.requestID.getID(),
+		Request:   params,
+		Headers:   make(map[string]string),
+		Start:     time.Now(),
 	}
 
-	return newPsyNetRPC(conn, playerID, p.requestID, p.logger), nil
+	invoke := chainInterceptors(p.interceptors, func(ctx context.Context, info *CallInfo, result interface{}) error {
+		err := p.post(ctx, path, info.RequestID, info.Headers, info.Request, result)
+		return annotateError(err, info)
+	})
+	return invoke(ctx, info, result)
 }
 
-func (p *PsyNet) postJSON(path []string, params interface{}, result interface{}) error {
-	url := fmt.Sprintf("%s/%s", baseURL, strings.Join(path, "/"))
+// post sends a signed request to the HTTP API, headers are added on top of the standard Psy headers.
+func (p *PsyNet) post(ctx context.Context, path []string, requestID string, headers map[string]string, params interface{}, result interface{}) error {
+	url := fmt.Sprintf("%s/%s", p.baseURL, strings.Join(path, "/"))
 
 	body, err := json.Marshal(params)
 	if err != nil {
@@ -132,50 +214,58 @@ func (p *PsyNet) postJSON(path []string, params interface{}, result interface{})
 
 	p.logger.Debug("sending http request", slog.String("url", url), slog.String("body", string(body)))
 
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
+	userAgent := p.userAgent
+	if userAgent == "" {
+		userAgent = fmt.Sprintf("RL Win/%s gzip (x86_64-pc-win32) curl-7.67.0 Schannel", p.gameVersion)
+	}
+
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("User-Agent", fmt.Sprintf("RL Win/%s gzip (x86_64-pc-win32) curl-7.67.0 Schannel", p.gameVersion))
+	req.Header.Set("User-Agent", userAgent)
 	req.Header.Set("PsyBuildID", p.buildID)
-	req.Header.Set("PsyEnvironment", "Prod")
-	req.Header.Set("PsyRequestID", p.requestID.getID())
-	req.Header.Set("PsySig", generatePsySig(body))
+	req.Header.Set("PsyEnvironment", p.environment)
+	req.Header.Set("PsyRequestID", requestID)
+	req.Header.Set("PsySig", generatePsySig(p.sigKey, body))
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
 
 	resp, err := p.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return fmt.Errorf("%w: failed to send request: %w", ErrTransport, err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status: %s", resp.Status)
-	}
-
 	respBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return fmt.Errorf("%w: failed to read response body: %w", ErrTransport, err)
 	}
 
-	p.logger.Debug("received http response", slog.String("status", resp.Status), slog.String("body", string(respBytes)))
-
-	var wrapper struct {
-		Result json.RawMessage `json:"Result"`
-		Error  *psyNetError    `json:"Error"`
-	}
-	if err := json.Unmarshal(respBytes, &wrapper); err != nil {
-		return fmt.Errorf("failed to unmarshal wrapper: %w", err)
+	if resp.StatusCode != http.StatusOK {
+		return &HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       respBytes,
+		}
 	}
 
-	if wrapper.Error != nil {
-		return wrapper.Error
+	if p.responseSigKey != "" {
+		psyTime, psySig := resp.Header.Get("PsyTime"), resp.Header.Get("PsySig")
+		if !verifyPsySig(p.responseSigKey, psyTime, psySig, respBytes) {
+			return &SignatureError{
+				Service:   strings.Join(path, "/"),
+				RequestID: requestID,
+				PsyTime:   psyTime,
+				PsySig:    psySig,
+			}
+		}
 	}
 
-	if err := json.Unmarshal(wrapper.Result, result); err != nil {
-		return fmt.Errorf("failed to unmarshal result: %w", err)
-	}
+	p.logger.Debug("received http response", slog.String("status", resp.Status), slog.String("body", string(respBytes)))
 
-	return nil
+	return decodeResponse(respBytes, result)
 }
@@ -1,13 +1,14 @@
 package rlapi
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net"
-	"strings"
+	"slices"
 	"sync"
 	"time"
 
@@ -21,132 +22,210 @@ type EventType int
 const (
 	EventTypeDisconnected EventType = iota
 	EventTypeMessage
+	EventTypeReconnecting
+	EventTypeReconnected
 )
 
 // Event represents connection events or raw messages from the server
 type Event struct {
-	Type    EventType
+	Type EventType
+	// Service is the PsyService header of a pushed message, empty for connection events.
+	Service string
 	Content string
 }
 
 // PsyNetRPC represents an authenticated WebSocket connection.
 type PsyNetRPC struct {
 	wsConn *websocket.Conn
+	writer *writePump
 	mu     sync.Mutex
 	logger *slog.Logger
 
 	pingTimer   *time.Timer
 	pongChan    chan struct{}
-	eventCh     chan *Event
-	pendingReqs map[string]chan *PsyResponse
+	events      *Subscription
+	pendingReqs map[string]*pendingRequest
 
 	requestID     *requestIDCounter
 	localPlayerID PlayerID
 	connected     bool
+	closed        bool
+	draining      bool
+	drained       chan struct{}
+
+	// session state used to re-establish the connection
+	psyNet    *PsyNet
+	url       string
+	psyToken  string
+	sessionID string
+	authReq   *AuthPlayerRequest
+
+	reconnectPolicy *ReconnectPolicy
+	stopReconnect   context.CancelFunc
+
+	// transport replaces the WebSocket for service calls when set
+	transport rpcTransport
+
+	sigKey         string
+	pingInterval   time.Duration
+	pongTimeout    time.Duration
+	requestTimeout time.Duration
+	writeTimeout   time.Duration
+	writeQueue     int
+
+	interceptors   []Interceptor
+	limiter        *rateLimiter
+	retryPolicy    *RetryPolicy
+	responseSigKey string
+
+	subsMu sync.RWMutex
+	subs   []*Subscription
+
+	health connHealth
 }
 
-func newPsyNetRPC(wsConn *websocket.Conn, localPlayerID PlayerID, requestID *requestIDCounter, logger *slog.Logger) *PsyNetRPC {
-	return &PsyNetRPC{
-		wsConn:        wsConn,
-		localPlayerID: localPlayerID,
-		requestID:     requestID,
-		pendingReqs:   make(map[string]chan *PsyResponse),
-		pongChan:      make(chan struct{}, 1),
-		eventCh:       make(chan *Event, 32),
-		connected:     true,
-		logger:        logger,
+// pendingRequest tracks a request awaiting its response.
+// The entry is removed by whichever comes first: the response, the caller's context, the request timeout or the connection closing.
+type pendingRequest struct {
+	// the response is delivered to ch, or to call for CallAsync
+	ch      chan *PsyResponse
+	call    *PendingCall
+	service string
+	sentAt  time.Time
+
+	stopCtx func() bool
+	timer   *time.Timer
+}
+
+// complete delivers the response, nil when the request ended without one.
+func (r *pendingRequest) complete(response *PsyResponse) {
+	switch {
+	case r.call != nil:
+		r.call.complete(response)
+	case response == nil:
+		close(r.ch)
+	default:
+		r.ch <- response
+	}
+}
+
+func newPsyNetRPC(wsConn *websocket.Conn, localPlayerID PlayerID, psyNet *PsyNet) *PsyNetRPC {
+	rpc := &PsyNetRPC{
+		wsConn:         wsConn,
+		localPlayerID:  localPlayerID,
+		requestID:      psyNet.requestID,
+		pendingReqs:    make(map[string]*pendingRequest),
+		pongChan:       make(chan struct{}, 1),
+		connected:      true,
+		logger:         psyNet.logger,
+		psyNet:         psyNet,
+		sigKey:         psyNet.sigKey,
+		pingInterval:   psyNet.pingInterval,
+		pongTimeout:    psyNet.pongTimeout,
+		requestTimeout: psyNet.requestTimeout,
+		writeTimeout:   psyNet.writeTimeout,
+		writeQueue:     psyNet.writeQueue,
+		interceptors:   psyNet.interceptors,
+		responseSigKey: psyNet.responseSigKey,
+	}
+	rpc.events = rpc.Subscribe(SubscribeOptions{Buffer: psyNet.eventBuffer})
+	rpc.health.init()
+	if wsConn != nil {
+		rpc.startWriter(wsConn)
+	}
+	return rpc
+}
+
+// startWriter starts the write pump of a new socket, p.mu must be held once the connection is shared.
+func (p *PsyNetRPC) startWriter(conn *websocket.Conn) {
+	p.writer = newWritePump(conn, p.writeTimeout, p.writeQueue, func(n int) {
+		p.health.bytesOut.Add(uint64(n))
+	})
+}
+
+// stopWriter stops the write pump, p.mu must be held.
+func (p *PsyNetRPC) stopWriter() {
+	if p.writer != nil {
+		p.writer.close()
+		p.writer = nil
 	}
 }
 
 func (p *PsyNetRPC) IsConnected() bool {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	return p.connected && p.wsConn != nil
+	return p.connected && (p.wsConn != nil || p.transport != nil)
 }
 
 func (p *PsyNetRPC) Close() error {
 	p.mu.Lock()
-	if p.wsConn != nil && p.connected {
-		_ = p.wsConn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
-		err := p.wsConn.Close()
-
-		// clean up pending events
-		p.connected = false
-
-		if p.pingTimer != nil {
-			p.pingTimer.Stop()
-			p.pingTimer = nil
-		}
-
-		for reqID, ch := range p.pendingReqs {
-			close(ch)
-			delete(p.pendingReqs, reqID)
-		}
-
+	if p.closed {
 		p.mu.Unlock()
-		p.sendEvent(EventTypeDisconnected, "")
-
-		return err
+		return nil
 	}
+	p.closed = true
 
-	p.mu.Unlock()
-	return nil
-}
-
-func (p *PsyNetRPC) parseMessage(message string) (*PsyResponse, error) {
-	delimiter := "\r\n\r\n"
-	index := strings.Index(message, delimiter)
-	if index == -1 {
-		return nil, fmt.Errorf("message does not contain expected delimiter")
+	if p.stopReconnect != nil {
+		p.stopReconnect()
+		p.stopReconnect = nil
 	}
 
-	headersPart := message[:index]
-	jsonPayload := message[index+len(delimiter):]
-
-	headers := make(map[string]string)
-	headerLines := strings.Split(headersPart, "\r\n")
-	for _, line := range headerLines {
-		if colonIndex := strings.Index(line, ":"); colonIndex != -1 {
-			key := strings.TrimSpace(line[:colonIndex])
-			value := strings.TrimSpace(line[colonIndex+1:])
-			headers[key] = value
+	var err error
+	p.stopWriter()
+	if p.wsConn != nil && p.connected {
+		// control frames may be written alongside the pump
+		timeout := p.writeTimeout
+		if timeout <= 0 {
+			timeout = defaultWriteTimeout
 		}
+		deadline := time.Now().Add(timeout)
+		_ = p.wsConn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), deadline)
+		err = p.wsConn.Close()
 	}
 
-	responseID := headers["PsyResponseID"]
+	// clean up pending events
+	p.connected = false
+	p.stopPing()
+	p.failPending()
 
-	var jsonResult PsyResponse
-	if err := json.Unmarshal([]byte(jsonPayload), &jsonResult); err != nil {
-		return nil, fmt.Errorf("failed to parse json payload: %w", err)
-	}
+	p.mu.Unlock()
+	p.setState(StateClosed)
+	p.sendEvent(EventTypeDisconnected, "")
 
-	jsonResult.ResponseID = responseID
+	return err
+}
 
-	return &jsonResult, nil
+// stopPing cancels the scheduled ping, p.mu must be held.
+func (p *PsyNetRPC) stopPing() {
+	if p.pingTimer != nil {
+		p.pingTimer.Stop()
+		p.pingTimer = nil
+	}
 }
 
-func (p *PsyNetRPC) buildMessage(headers map[string]string, body interface{}) (string, error) {
-	var message strings.Builder
-	var jsonData []byte
+// failPending closes every pending request channel, p.mu must be held.
+func (p *PsyNetRPC) failPending() {
+	for reqID, req := range p.pendingReqs {
+		req.complete(nil)
+		p.removePending(reqID)
+	}
+}
 
-	if body != nil {
-		var err error
-		jsonData, err = json.Marshal(body)
-		if err != nil {
-			return "", fmt.Errorf("failed to marshal body: %w", err)
+// removePending forgets a pending request and signals Shutdown once none are left, p.mu must be held.
+func (p *PsyNetRPC) removePending(requestID string) {
+	if req, ok := p.pendingReqs[requestID]; ok {
+		if req.stopCtx != nil {
+			req.stopCtx()
+		}
+		if req.timer != nil {
+			req.timer.Stop()
 		}
-
-		headers["PsySig"] = generatePsySig(jsonData)
 	}
-
-	for key, value := range headers {
-		message.WriteString(fmt.Sprintf("%s: %s\r\n", key, value))
+	delete(p.pendingReqs, requestID)
+	if p.drained != nil && len(p.pendingReqs) == 0 {
+		close(p.drained)
+		p.drained = nil
 	}
-
-	message.WriteString("\r\n")
-	message.Write(jsonData)
-
-	return message.String(), nil
 }
 
 func (p *PsyNetRPC) schedulePing() {
@@ -157,49 +236,90 @@ func (p *PsyNetRPC) schedulePing() {
 		return
 	}
 
-	p.pingTimer = time.AfterFunc(pingInterval, p.sendPing)
+	p.stopPing()
+	p.pingTimer = time.AfterFunc(p.pingInterval, p.sendPing)
 }
 
 func (p *PsyNetRPC) sendPing() {
-	pingMessage, err := p.buildMessage(map[string]string{"PsyPing": ""}, nil)
+	pingMessage, err := p.buildMessage([][2]string{{"PsyPing", ""}}, nil)
 	if err != nil {
 		p.logger.Error("failed to build ping message", slog.Any("err", err))
 		return
 	}
 
 	p.mu.Lock()
-	if !p.connected || p.wsConn == nil {
+	if !p.connected || p.wsConn == nil || p.writer == nil {
 		p.logger.Error("connection lost while preparing to ping")
 		p.mu.Unlock()
 		return
 	}
-	if err := p.wsConn.WriteMessage(websocket.TextMessage, []byte(pingMessage)); err != nil {
+	conn := p.wsConn
+	writer := p.writer
+	p.mu.Unlock()
+
+	if err := writer.sendPriority(context.Background(), pingMessage); err != nil {
 		p.logger.Error("failed to send ping", slog.Any("err", err))
-		p.mu.Unlock()
 		return
 	}
-	p.mu.Unlock()
 
+	sentAt := time.Now()
 	p.logger.Debug("sent ping")
 
-	select {
-	case <-p.pongChan:
-		p.logger.Debug("received pong")
-		p.schedulePing()
-	case <-time.After(pongTimeout):
-		p.logger.Error("pong timeout reached")
-		_ = p.Close()
-		return
+	degraded := time.NewTimer(p.pongTimeout / 2)
+	defer degraded.Stop()
+	timeout := time.NewTimer(p.pongTimeout)
+	defer timeout.Stop()
+
+	// the loop belongs to conn, it ends when the connection's writer is stopped by connectionLost or Close
+	// so it never touches the state or pongs of a socket installed by a reconnect
+	current := func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.connected && p.wsConn == conn && p.writer == writer
+	}
+
+	for {
+		select {
+		case <-writer.stop:
+			return
+		case <-p.pongChan:
+			if !current() {
+				// the pong answers the new socket's ping, hand it back
+				select {
+				case p.pongChan <- struct{}{}:
+				default:
+				}
+				return
+			}
+
+			p.logger.Debug("received pong")
+			p.health.recordPong(time.Since(sentAt))
+			p.setState(StateConnected)
+			p.schedulePing()
+			return
+		case <-degraded.C:
+			if !current() {
+				return
+			}
+			p.logger.Warn("pong is late")
+			p.setState(StateDegraded)
+		case <-timeout.C:
+			p.logger.Error("pong timeout reached")
+			p.connectionLost(conn)
+			return
+		}
 	}
 }
 
 func (p *PsyNetRPC) readMessages() {
-	defer func() {
-		_ = p.Close()
-	}()
+	p.mu.Lock()
+	conn := p.wsConn
+	p.mu.Unlock()
+
+	defer p.connectionLost(conn)
 
 	for {
-		_, message, err := p.wsConn.ReadMessage()
+		_, message, err := conn.ReadMessage()
 		if err != nil {
 			if errors.Is(err, net.ErrClosed) {
 				p.logger.Debug("websocket closed", slog.Any("err", err))
@@ -209,7 +329,9 @@ func (p *PsyNetRPC) readMessages() {
 			break
 		}
 
-		if strings.HasPrefix(string(message), "PsyPong:") {
+		p.health.bytesIn.Add(uint64(len(message)))
+
+		if bytes.HasPrefix(message, pongPrefix) {
 			select {
 			case p.pongChan <- struct{}{}:
 			default:
@@ -217,9 +339,11 @@ func (p *PsyNetRPC) readMessages() {
 			continue
 		}
 
-		p.logger.Debug("received websocket response", slog.String("message", string(message)))
+		if p.logger.Enabled(context.Background(), slog.LevelDebug) {
+			p.logger.Debug("received websocket response", slog.String("message", string(message)))
+		}
 
-		response, err := p.parseMessage(string(message))
+		response, err := p.parseMessage(message)
 		if err != nil {
 			p.logger.Error("failed to parse psynet message", slog.Any("err", err), slog.String("message", string(message)))
 			p.sendEvent(EventTypeMessage, string(message))
@@ -228,109 +352,229 @@ func (p *PsyNetRPC) readMessages() {
 
 		if response.ResponseID != "" {
 			p.mu.Lock()
-			ch, exists := p.pendingReqs[response.ResponseID]
+			req, exists := p.pendingReqs[response.ResponseID]
+			p.removePending(response.ResponseID)
 			p.mu.Unlock()
 
 			if exists {
-				ch <- response
+				req.complete(response)
 				continue
 			}
 		}
 
-		p.sendEvent(EventTypeMessage, string(message))
+		if response.err != nil {
+			p.logger.Warn("dropping pushed message", slog.Any("err", response.err), slog.String("message", string(message)))
+			continue
+		}
+
+		p.publish(&Event{
+			Type:    EventTypeMessage,
+			Service: response.service,
+			Content: string(message),
+		})
 	}
 }
 
 func (p *PsyNetRPC) sendRequestAsync(ctx context.Context, service string, data interface{}) (<-chan *PsyResponse, error) {
-	if !p.IsConnected() {
-		return nil, fmt.Errorf("websocket connection not established")
+	return p.send(ctx, p.newCallInfo(service, data))
+}
+
+func (p *PsyNetRPC) send(ctx context.Context, info *CallInfo) (<-chan *PsyResponse, error) {
+	respCh := make(chan *PsyResponse, 1)
+	if err := p.sendPending(ctx, info, &pendingRequest{ch: respCh}); err != nil {
+		return nil, err
 	}
+	return respCh, nil
+}
 
-	requestID := p.requestID.getID()
-	p.logger.Debug("sending websocket request", slog.String("requestID", requestID), slog.String("service", service), slog.Any("data", data))
+// sendPending registers req under the request ID and writes the request, req receives the response once it is routed.
+func (p *PsyNetRPC) sendPending(ctx context.Context, info *CallInfo, req *pendingRequest) error {
+	if !p.IsConnected() {
+		return ErrNotConnected
+	}
 
-	respCh := make(chan *PsyResponse, 1)
+	requestID := info.RequestID
+	p.logger.Debug("sending websocket request", slog.String("requestID", requestID), slog.String("service", info.Service), slog.Any("data", info.Request))
 
-	headers := map[string]string{
-		"PsyService":   service,
-		"PsyRequestID": requestID,
+	headers := make([][2]string, 0, len(info.Headers)+2)
+	headers = append(headers, [2]string{"PsyService", info.Service}, [2]string{"PsyRequestID", requestID})
+	for _, key := range slices.Sorted(maps.Keys(info.Headers)) {
+		if key != "PsyService" && key != "PsyRequestID" {
+			headers = append(headers, [2]string{key, info.Headers[key]})
+		}
 	}
-	message, err := p.buildMessage(headers, data)
+	message, err := p.buildMessage(headers, info.Request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to buildm message: %w", err)
+		return fmt.Errorf("failed to buildm message: %w", err)
 	}
 
 	p.mu.Lock()
-	if !p.connected || p.wsConn == nil {
+	if p.draining {
 		p.mu.Unlock()
-		return nil, fmt.Errorf("connection lost while preparing to send")
+		return ErrShuttingDown
 	}
-
-	p.pendingReqs[requestID] = respCh
-	err = p.wsConn.WriteMessage(websocket.TextMessage, []byte(message))
-	if err != nil {
-		delete(p.pendingReqs, requestID)
+	if !p.connected || p.wsConn == nil || p.writer == nil {
 		p.mu.Unlock()
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return fmt.Errorf("%w: connection lost while preparing to send", ErrTransport)
+	}
+	writer := p.writer
+
+	req.service = info.Service
+	req.sentAt = time.Now()
+	p.pendingReqs[requestID] = req
+
+	// neither callback runs a goroutine until it fires, both are stopped once the entry is removed
+	req.stopCtx = context.AfterFunc(ctx, func() {
+		p.expirePending(requestID, nil)
+	})
+	// the default only bounds calls without a deadline of their own, a longer one is the caller's choice
+	if _, ok := ctx.Deadline(); p.requestTimeout > 0 && !ok {
+		req.timer = time.AfterFunc(p.requestTimeout, func() {
+			p.expirePending(requestID, ErrRequestTimeout)
+		})
 	}
 
 	p.mu.Unlock()
 
-	go func() {
-		<-ctx.Done()
+	// the write happens on the pump, p.mu stays free for response routing meanwhile
+	if err := writer.send(ctx, message); err != nil {
 		p.mu.Lock()
-		ch := p.pendingReqs[requestID]
-		delete(p.pendingReqs, requestID)
+		p.removePending(requestID)
 		p.mu.Unlock()
-
-		if ch != nil {
-			close(ch)
+		if ctx.Err() != nil {
+			return err
 		}
-	}()
+		return fmt.Errorf("%w: failed to send request: %w", ErrTransport, err)
+	}
 
-	return respCh, nil
+	return nil
+}
+
+// expirePending fails a request that is still pending, with err or by closing its channel when the caller's context is done.
+func (p *PsyNetRPC) expirePending(requestID string, err error) {
+	p.mu.Lock()
+	req, ok := p.pendingReqs[requestID]
+	p.removePending(requestID)
+	p.mu.Unlock()
+
+	if !ok {
+		return
+	}
+	if err == nil {
+		req.complete(nil)
+		return
+	}
+	req.complete(&PsyResponse{err: err})
 }
 
 func (p *PsyNetRPC) awaitResponse(ctx context.Context, respCh <-chan *PsyResponse, result interface{}) error {
 	select {
-	case response := <-respCh:
-		if response.Error != nil {
-			return response.Error
+	case response, ok := <-respCh:
+		if !ok {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return ErrConnectionClosed
 		}
 
-		if err := json.Unmarshal(response.Result, result); err != nil {
-			return fmt.Errorf("failed to unmarshal response: %w", err)
+		if response.err != nil {
+			return response.err
 		}
 
-		return nil
+		return decodeResponse(response.body, result)
 	case <-ctx.Done():
 		return ctx.Err()
 	}
 }
 
 func (p *PsyNetRPC) sendRequestSync(ctx context.Context, service string, data interface{}, result interface{}) error {
-	respCh, err := p.sendRequestAsync(ctx, service, data)
+	p.mu.Lock()
+	retry := p.retryPolicy
+	p.mu.Unlock()
+
+	if retry == nil || !retry.idempotent(service) {
+		return p.sendOnce(ctx, service, data, result)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := p.sendOnce(ctx, service, data, result)
+		if err == nil || attempt+1 >= retry.attempts() || !retry.retryable(err) {
+			return err
+		}
+
+		p.logger.Debug("retrying request", slog.String("service", service), slog.Int("attempt", attempt+1), slog.Any("err", err))
+
+		select {
+		case <-time.After(retry.backoff(attempt)):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// sendOnce runs a single attempt through the rate limiter and interceptor chain.
+func (p *PsyNetRPC) sendOnce(ctx context.Context, service string, data interface{}, result interface{}) error {
+	p.mu.Lock()
+	interceptors := p.interceptors
+	limiter := p.limiter
+	p.mu.Unlock()
+
+	if limiter != nil {
+		if err := limiter.wait(ctx, service); err != nil {
+			return fmt.Errorf("failed to send request for service: %s, err: %w", service, err)
+		}
+	}
+
+	invoke := chainInterceptors(interceptors, p.invoke)
+	return invoke(ctx, p.newCallInfo(service, data), result)
+}
+
+// invoke sends the call over the active transport and waits for its result, it terminates the interceptor chain.
+func (p *PsyNetRPC) invoke(ctx context.Context, info *CallInfo, result interface{}) error {
+	if p.transport != nil {
+		if !p.IsConnected() {
+			return fmt.Errorf("failed to send request for service: %s, err: %w", info.Service, ErrConnectionClosed)
+		}
+
+		// track the call so Health and Shutdown see it, there is no response channel to deliver to
+		p.mu.Lock()
+		if p.draining {
+			p.mu.Unlock()
+			return ErrShuttingDown
+		}
+		p.pendingReqs[info.RequestID] = &pendingRequest{
+			ch:      make(chan *PsyResponse, 1),
+			service: info.Service,
+			sentAt:  time.Now(),
+		}
+		p.mu.Unlock()
+
+		defer func() {
+			p.mu.Lock()
+			p.removePending(info.RequestID)
+			p.mu.Unlock()
+		}()
+
+		return annotateError(p.transport.call(ctx, info, result), info)
+	}
+
+	respCh, err := p.send(ctx, info)
 	if err != nil {
-		return fmt.Errorf("failed to send async request for service: %s, err: %w", service, err)
+		return fmt.Errorf("failed to send async request for service: %s, err: %w", info.Service, err)
 	}
 
-	return p.awaitResponse(ctx, respCh, result)
+	return annotateError(p.awaitResponse(ctx, respCh, result), info)
 }
 
-// Events returns a channel that receives raw messages and connection events
+// Events returns a channel that receives raw messages and connection events.
+// The channel is shared by every caller, use Subscribe for an independent stream.
 func (p *PsyNetRPC) Events() <-chan *Event {
-	return p.eventCh
+	return p.events.Events()
 }
 
 func (p *PsyNetRPC) sendEvent(eventType EventType, content string) {
-	select {
-	case p.eventCh <- &Event{
+	p.publish(&Event{
 		Type:    eventType,
 		Content: content,
-	}:
-	default:
-		p.logger.Warn("event channel is full, dropping event",
-			slog.String("type", fmt.Sprintf("%d", eventType)),
-			slog.String("content", content))
-	}
+	})
 }
@@ -3,9 +3,11 @@ package rlapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"strings"
 	"sync"
 	"testing"
@@ -112,7 +114,7 @@ func TestPsyNetRPC_SendRequestSync(t *testing.T) {
 
 	// Create PsyNet instance and establish connection
 	psyNet := NewPsyNet()
-	rpc, err := psyNet.establishSocket(mockServer.URL(), "test-token", "test-session", "test-player")
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-token", "test-session", "test-player")
 	if err != nil {
 		t.Fatalf("Failed to establish socket: %v", err)
 	}
@@ -152,7 +154,7 @@ func TestPsyNetRPC_SendRequestAsync(t *testing.T) {
 
 	// Create PsyNet instance and establish connection
 	psyNet := NewPsyNet()
-	rpc, err := psyNet.establishSocket(mockServer.URL(), "test-token", "test-session", "test-player")
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-token", "test-session", "test-player")
 	if err != nil {
 		t.Fatalf("Failed to establish socket: %v", err)
 	}
@@ -196,7 +198,7 @@ func TestPsyNetRPC_ConcurrentRequests(t *testing.T) {
 
 	// Create PsyNet instance and establish connection
 	psyNet := NewPsyNet()
-	rpc, err := psyNet.establishSocket(mockServer.URL(), "test-token", "test-session", "test-player")
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-token", "test-session", "test-player")
 	if err != nil {
 		t.Fatalf("Failed to establish socket: %v", err)
 	}
@@ -264,7 +266,7 @@ func TestPsyNetRPC_FireAndForgetNoLeak(t *testing.T) {
 
 	// Create PsyNet instance and establish connection
 	psyNet := NewPsyNet()
-	rpc, err := psyNet.establishSocket(mockServer.URL(), "test-token", "test-session", "test-player")
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-token", "test-session", "test-player")
 	if err != nil {
 		t.Fatalf("Failed to establish socket: %v", err)
 	}
@@ -301,7 +303,7 @@ func TestPsyNetRPC_FireAndForgetNoLeak(t *testing.T) {
 	// Cancel context (simulates timeout/cancellation)
 	cancel()
 
-	// Wait a bit for cleanup goroutines to run
+	// Wait a bit for the cancellation callbacks to run
 	time.Sleep(200 * time.Millisecond)
 
 	// Check that pendingReqs was cleaned up
@@ -318,6 +320,99 @@ func TestPsyNetRPC_FireAndForgetNoLeak(t *testing.T) {
 		initialCount, midCount, finalCount)
 }
 
+func TestPsyNetRPC_RequestTimeout(t *testing.T) {
+	mockServer := NewMockWSServer()
+	defer mockServer.Close()
+
+	psyNet := NewPsyNet(WithRequestTimeout(50 * time.Millisecond))
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-token", "test-session", "test-player")
+	if err != nil {
+		t.Fatalf("Failed to establish socket: %v", err)
+	}
+
+	go rpc.readMessages()
+	defer rpc.Close()
+
+	// no response is set, only the default deadline ends the call
+	var result map[string]interface{}
+	err = rpc.sendRequestSync(context.Background(), "Test/NoResponse v1", map[string]interface{}{}, &result)
+	if !errors.Is(err, ErrRequestTimeout) {
+		t.Fatalf("sendRequestSync() error = %v, want ErrRequestTimeout", err)
+	}
+
+	rpc.mu.Lock()
+	pending := len(rpc.pendingReqs)
+	rpc.mu.Unlock()
+	if pending != 0 {
+		t.Errorf("pendingReqs = %d after timeout, want 0", pending)
+	}
+
+	// a caller's own deadline replaces the default, even when it is longer
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = rpc.sendRequestSync(ctx, "Test/NoResponse v1", map[string]interface{}{}, &result)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("sendRequestSync() error = %v, want the caller's deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("sendRequestSync() returned after %v, want the longer deadline kept", elapsed)
+	}
+}
+
+func TestPsyNetRPC_PendingWithoutGoroutines(t *testing.T) {
+	mockServer := NewMockWSServer()
+	defer mockServer.Close()
+
+	psyNet := NewPsyNet()
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-token", "test-session", "test-player")
+	if err != nil {
+		t.Fatalf("Failed to establish socket: %v", err)
+	}
+
+	go rpc.readMessages()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	before := runtime.NumGoroutine()
+	channels := make([]<-chan *PsyResponse, 0, 200)
+	for i := range 200 {
+		reqCtx := context.Background()
+		if i%2 == 0 {
+			reqCtx = ctx
+		}
+		respCh, err := rpc.sendRequestAsync(reqCtx, "Test/Pending v1", map[string]interface{}{})
+		if err != nil {
+			t.Fatalf("sendRequestAsync %d failed: %v", i, err)
+		}
+		channels = append(channels, respCh)
+	}
+	if after := runtime.NumGoroutine(); after > before+5 {
+		t.Errorf("goroutines grew from %d to %d for 200 pending requests", before, after)
+	}
+
+	rpc.Close()
+
+	for i, respCh := range channels {
+		select {
+		case _, ok := <-respCh:
+			if ok {
+				t.Errorf("request %d received a response after close", i)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("request %d was not failed by close", i)
+		}
+	}
+
+	rpc.mu.Lock()
+	pending := len(rpc.pendingReqs)
+	rpc.mu.Unlock()
+	if pending != 0 {
+		t.Errorf("pendingReqs = %d after close, want 0", pending)
+	}
+}
+
 func TestPsyNetRPC_RawMessage(t *testing.T) {
 	// Setup mock server
 	mockServer := NewMockWSServer()
@@ -325,7 +420,7 @@ func TestPsyNetRPC_RawMessage(t *testing.T) {
 
 	// Create PsyNet instance and establish connection
 	psyNet := NewPsyNet()
-	rpc, err := psyNet.establishSocket(mockServer.URL(), "test-token", "test-session", "test-player")
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-token", "test-session", "test-player")
 	if err != nil {
 		t.Fatalf("Failed to establish socket: %v", err)
 	}
@@ -339,7 +434,7 @@ func TestPsyNetRPC_RawMessage(t *testing.T) {
 		malformedMessage := "this is not a valid psynet message"
 
 		// Verify parseMessage fails
-		_, err = rpc.parseMessage(malformedMessage)
+		_, err = rpc.parseMessage([]byte(malformedMessage))
 		if err == nil {
 			t.Error("Expected parseMessage to fail on malformed message")
 		}
@@ -389,7 +484,7 @@ func TestPsyNetRPC_ConcurrentContextCancellation(t *testing.T) {
 
 	// Create PsyNet instance and establish connection
 	psyNet := NewPsyNet()
-	rpc, err := psyNet.establishSocket(mockServer.URL(), "test-token", "test-session", "test-player")
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-token", "test-session", "test-player")
 	if err != nil {
 		t.Fatalf("Failed to establish socket: %v", err)
 	}
@@ -453,7 +548,7 @@ func TestPsyNetRPC_ParseMessage(t *testing.T) {
 	t.Run("valid result", func(t *testing.T) {
 		input := fmt.Sprintf("PsyTime: %d\r\nPsySig: test_sig\r\nPsyResponseID: %s\r\n\r\n%s", time.Now().Unix(), "PsyNetMessage_X_1", `{"Result":{"Message":"ok"}}`)
 
-		resp, err := rpc.parseMessage(input)
+		resp, err := rpc.parseMessage([]byte(input))
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -467,9 +562,9 @@ func TestPsyNetRPC_ParseMessage(t *testing.T) {
 		var msg struct {
 			Message string
 		}
-		err = json.Unmarshal(resp.Result, &msg)
+		err = decodeResponse(resp.body, &msg)
 		if err != nil {
-			t.Fatalf("json error: %v", err)
+			t.Fatalf("decode error: %v", err)
 		}
 		if msg.Message != "ok" {
 			t.Errorf("message = %q, want %q", msg.Message, "ok")
@@ -479,7 +574,7 @@ func TestPsyNetRPC_ParseMessage(t *testing.T) {
 	t.Run("error payload", func(t *testing.T) {
 		input := fmt.Sprintf("PsyTime: %d\r\nPsySig: test_sig\r\nPsyResponseID: %s\r\n\r\n%s", time.Now().Unix(), "PsyNetMessage_X_1", `{"Error":{"Type":"InvalidParameters","Message":""}}`)
 
-		resp, err := rpc.parseMessage(input)
+		resp, err := rpc.parseMessage([]byte(input))
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -489,15 +584,20 @@ func TestPsyNetRPC_ParseMessage(t *testing.T) {
 		if resp.ResponseID != "PsyNetMessage_X_1" {
 			t.Errorf("ResponseID = %q, want %q", resp.ResponseID, "PsyNetMessage_X_1")
 		}
-		if resp.Error.Type != "InvalidParameters" {
-			t.Errorf("Error.Message = %q, want %q", resp.Error.Error(), "InvalidParameters")
+		var msg struct {
+			Message string
+		}
+		err = decodeResponse(resp.body, &msg)
+		var psyErr *PsyNetError
+		if !errors.As(err, &psyErr) || psyErr.Type != "InvalidParameters" {
+			t.Errorf("decode error = %v, want %q", err, "InvalidParameters")
 		}
 	})
 
 	t.Run("missing PsyResponseID header", func(t *testing.T) {
 		input := fmt.Sprintf("\r\n\r\n%s", `{"Result":{"Message":"ok"}}`)
 
-		resp, err := rpc.parseMessage(input)
+		resp, err := rpc.parseMessage([]byte(input))
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -511,9 +611,9 @@ func TestPsyNetRPC_ParseMessage(t *testing.T) {
 		var msg struct {
 			Message string
 		}
-		err = json.Unmarshal(resp.Result, &msg)
+		err = decodeResponse(resp.body, &msg)
 		if err != nil {
-			t.Fatalf("json error: %v", err)
+			t.Fatalf("decode error: %v", err)
 		}
 		if msg.Message != "ok" {
 			t.Errorf("message = %q, want %q", msg.Message, "ok")
@@ -525,22 +625,22 @@ func TestPsyNetRPC_BuildMessage(t *testing.T) {
 	rpc := &PsyNetRPC{}
 
 	t.Run("request with no body", func(t *testing.T) {
-		headers := map[string]string{"PsyPing": ""}
+		headers := [][2]string{{"PsyPing", ""}}
 		message, err := rpc.buildMessage(headers, nil)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 
 		expected := "PsyPing: \r\n\r\n"
-		if message != expected {
+		if string(message) != expected {
 			t.Errorf("message = %q, want %q", message, expected)
 		}
 	})
 
 	t.Run("request with body", func(t *testing.T) {
-		headers := map[string]string{
-			"PsyService":   "Shops/GetStandardShops",
-			"PsyRequestID": "PsyNetMessage_X_123",
+		headers := [][2]string{
+			{"PsyService", "Shops/GetStandardShops"},
+			{"PsyRequestID", "PsyNetMessage_X_123"},
 		}
 		requestData := map[string]interface{}{"test": "data"}
 
@@ -549,25 +649,14 @@ func TestPsyNetRPC_BuildMessage(t *testing.T) {
 			t.Fatalf("unexpected error: %v", err)
 		}
 
-		// Should contain all headers including auto-generated PsySig
-		if !strings.Contains(message, "PsyService: Shops/GetStandardShops\r\n") {
-			t.Error("missing PsyService header")
-		}
-		if !strings.Contains(message, "PsyRequestID: PsyNetMessage_X_123\r\n") {
-			t.Error("missing PsyRequestID header")
-		}
-		if !strings.Contains(message, "PsySig:") {
-			t.Error("missing PsySig header")
-		}
-
-		// Should contain JSON body
-		if !strings.Contains(message, `{"test":"data"}`) {
-			t.Error("missing JSON body")
-		}
-
-		// Should have proper structure with delimiter
-		if !strings.Contains(message, "\r\n\r\n") {
-			t.Error("missing header/body delimiter")
+		// headers keep their order, the auto-generated PsySig comes last
+		body := `{"test":"data"}`
+		expected := "PsyService: Shops/GetStandardShops\r\n" +
+			"PsyRequestID: PsyNetMessage_X_123\r\n" +
+			"PsySig: " + generatePsySig(rpc.sigKey, []byte(body)) + "\r\n" +
+			"\r\n" + body
+		if string(message) != expected {
+			t.Errorf("message = %q, want %q", message, expected)
 		}
 	})
 }
@@ -577,7 +666,7 @@ func TestPsyNetRPC_IsConnected(t *testing.T) {
 	defer mockServer.Close()
 
 	psyNet := NewPsyNet()
-	rpc, err := psyNet.establishSocket(mockServer.URL(), "test-token", "test-session", "test-player")
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-token", "test-session", "test-player")
 	if err != nil {
 		t.Fatalf("Failed to establish socket: %v", err)
 	}
@@ -603,7 +692,7 @@ func TestPsyNetRPC_PingPongHandling(t *testing.T) {
 	defer mockServer.Close()
 
 	psyNet := NewPsyNet()
-	rpc, err := psyNet.establishSocket(mockServer.URL(), "test-token", "test-session", "test-player")
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-token", "test-session", "test-player")
 	if err != nil {
 		t.Fatalf("Failed to establish socket: %v", err)
 	}
@@ -0,0 +1,8 @@
+package rlapi
+
+// Services of messages pushed by the server without a matching request. Only the names are known so far,
+// payloads are delivered raw, see Dispatcher.
+const (
+	// PushPartySystem is pushed on party changes, it has a non-standard schema that isn't understood yet.
+	PushPartySystem = "Party/System"
+)
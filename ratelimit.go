@@ -0,0 +1,197 @@
+package rlapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrRateLimitExceeded is returned when a call can't get a token from the client-side limiter in time.
+var ErrRateLimitExceeded = fmt.Errorf("%w: client-side limit exceeded", ErrRateLimited)
+
+// RateLimit configures a token bucket refilled with Rate tokens per second, holding up to Burst tokens.
+// A zero Rate disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimits configures client-side throttling of service calls.
+type RateLimits struct {
+	// Global limits every call on the connection.
+	Global RateLimit
+	// Services limits individual services, keyed by name with or without version, e.g. "Skills/GetPlayersSkills".
+	Services map[string]RateLimit
+	// FailFast returns ErrRateLimitExceeded instead of waiting when no token is available.
+	FailFast bool
+}
+
+// RateLimitQueue reports the calls currently waiting for a token.
+type RateLimitQueue struct {
+	Waiting   int
+	ByService map[string]int
+}
+
+// SetRateLimits enables client-side rate limiting, nil disables it.
+// Calls wait for a token until their context is done, and fail immediately with ErrRateLimitExceeded
+// if the context deadline would pass before a token is available.
+func (p *PsyNetRPC) SetRateLimits(limits *RateLimits) {
+	var limiter *rateLimiter
+	if limits != nil {
+		limiter = newRateLimiter(*limits)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.limiter = limiter
+}
+
+// RateLimitQueue returns the calls currently blocked by the rate limiter.
+func (p *PsyNetRPC) RateLimitQueue() RateLimitQueue {
+	p.mu.Lock()
+	limiter := p.limiter
+	p.mu.Unlock()
+
+	if limiter == nil {
+		return RateLimitQueue{ByService: map[string]int{}}
+	}
+	return limiter.queue()
+}
+
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.Rate <= 0 {
+		return nil
+	}
+	burst := math.Max(float64(limit.Burst), 1)
+	return &tokenBucket{
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long until it is available.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// release returns a reserved token that won't be used.
+func (b *tokenBucket) release() {
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+type rateLimiter struct {
+	mu       sync.Mutex
+	global   *tokenBucket
+	services map[string]*tokenBucket
+	failFast bool
+	waiting  map[string]int
+}
+
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	l := &rateLimiter{
+		global:   newTokenBucket(limits.Global),
+		services: make(map[string]*tokenBucket),
+		failFast: limits.FailFast,
+		waiting:  make(map[string]int),
+	}
+	for service, limit := range limits.Services {
+		if bucket := newTokenBucket(limit); bucket != nil {
+			l.services[service] = bucket
+		}
+	}
+	return l
+}
+
+func (l *rateLimiter) buckets(service string) []*tokenBucket {
+	var buckets []*tokenBucket
+	if l.global != nil {
+		buckets = append(buckets, l.global)
+	}
+	if bucket, ok := l.services[service]; ok {
+		buckets = append(buckets, bucket)
+	} else if bucket, ok := l.services[serviceBaseName(service)]; ok {
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
+
+// wait blocks until the service may be called or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context, service string) error {
+	now := time.Now()
+
+	l.mu.Lock()
+	buckets := l.buckets(service)
+	var delay time.Duration
+	for _, bucket := range buckets {
+		delay = max(delay, bucket.reserve(now))
+	}
+
+	if delay == 0 {
+		l.mu.Unlock()
+		return nil
+	}
+
+	deadline, hasDeadline := ctx.Deadline()
+	if l.failFast || (hasDeadline && deadline.Before(now.Add(delay))) {
+		for _, bucket := range buckets {
+			bucket.release()
+		}
+		l.mu.Unlock()
+		return ErrRateLimitExceeded
+	}
+
+	l.waiting[service]++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	l.mu.Lock()
+	if l.waiting[service]--; l.waiting[service] == 0 {
+		delete(l.waiting, service)
+	}
+	if err != nil {
+		for _, bucket := range buckets {
+			bucket.release()
+		}
+	}
+	l.mu.Unlock()
+
+	return err
+}
+
+func (l *rateLimiter) queue() RateLimitQueue {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	queue := RateLimitQueue{ByService: make(map[string]int, len(l.waiting))}
+	for service, n := range l.waiting {
+		queue.Waiting += n
+		queue.ByService[service] = n
+	}
+	return queue
+}
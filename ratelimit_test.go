@@ -0,0 +1,59 @@
+package rlapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := newRateLimiter(RateLimits{
+		Global: RateLimit{Rate: 1000, Burst: 10},
+		Services: map[string]RateLimit{
+			"Skills/GetPlayersSkills": {Rate: 20, Burst: 1},
+		},
+	})
+
+	ctx := context.Background()
+	if err := limiter.wait(ctx, "Skills/GetPlayersSkills v1"); err != nil {
+		t.Fatalf("first call failed: %v", err)
+	}
+
+	start := time.Now()
+	if err := limiter.wait(ctx, "Skills/GetPlayersSkills v1"); err != nil {
+		t.Fatalf("second call failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("second call waited %v, want ~50ms", elapsed)
+	}
+
+	// other services only share the global bucket
+	start = time.Now()
+	if err := limiter.wait(ctx, "Players/GetProfile v1"); err != nil {
+		t.Fatalf("unrelated call failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Millisecond {
+		t.Errorf("unrelated call waited %v, want no wait", elapsed)
+	}
+}
+
+func TestRateLimiter_Deadline(t *testing.T) {
+	limiter := newRateLimiter(RateLimits{
+		Global: RateLimit{Rate: 1, Burst: 1},
+	})
+
+	if err := limiter.wait(context.Background(), "Test/Service v1"); err != nil {
+		t.Fatalf("first call failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.wait(ctx, "Test/Service v1"); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Errorf("err = %v, want %v", err, ErrRateLimitExceeded)
+	}
+	if queue := limiter.queue(); queue.Waiting != 0 {
+		t.Errorf("Waiting = %d, want 0", queue.Waiting)
+	}
+}
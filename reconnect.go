@@ -0,0 +1,187 @@
+package rlapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultReconnectInitialBackoff = 1 * time.Second
+	defaultReconnectMaxBackoff     = 1 * time.Minute
+	defaultReconnectJitter         = 0.2
+)
+
+// CredentialSource returns a fresh auth ticket for re-authenticating when PsyNet rejects the stored PsyToken.
+// For Epic this is an EOS access token, for Steam a session ticket.
+type CredentialSource func(ctx context.Context) (string, error)
+
+// ReconnectPolicy configures automatic reconnects for PsyNetRPC. Zero values fall back to defaults.
+type ReconnectPolicy struct {
+	// InitialBackoff is the delay before the first attempt, doubled after every failure. Defaults to 1s.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts. Defaults to 1m.
+	MaxBackoff time.Duration
+	// Jitter is the fraction of each delay that is randomized, between 0 and 1. Defaults to 0.2.
+	Jitter float64
+	// MaxAttempts is the number of attempts before giving up and closing, 0 retries forever.
+	MaxAttempts int
+	// Credentials is used to run a full AuthPlayer when the stored PsyToken is rejected, optional.
+	Credentials CredentialSource
+}
+
+func (r ReconnectPolicy) backoff(attempt int) time.Duration {
+	initial := r.InitialBackoff
+	if initial <= 0 {
+		initial = defaultReconnectInitialBackoff
+	}
+	maxBackoff := r.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultReconnectMaxBackoff
+	}
+	jitter := r.Jitter
+	if jitter <= 0 || jitter > 1 {
+		jitter = defaultReconnectJitter
+	}
+
+	delay := initial
+	for range attempt {
+		delay *= 2
+		if delay >= maxBackoff {
+			delay = maxBackoff
+			break
+		}
+	}
+
+	spread := float64(delay) * jitter
+	return time.Duration(float64(delay) - spread + rand.Float64()*2*spread)
+}
+
+// SetReconnectPolicy enables automatic reconnects when the connection drops or a pong times out, nil disables them.
+// While reconnecting, EventTypeReconnecting is emitted and pending requests fail. Once the socket is back,
+// EventTypeReconnected is emitted so callers can resubscribe.
+func (p *PsyNetRPC) SetReconnectPolicy(policy *ReconnectPolicy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reconnectPolicy = policy
+}
+
+// connectionLost handles a dropped socket, reconnecting if a policy is set and closing otherwise.
+func (p *PsyNetRPC) connectionLost(conn *websocket.Conn) {
+	p.mu.Lock()
+	if p.closed || !p.connected || p.wsConn != conn {
+		p.mu.Unlock()
+		return
+	}
+
+	if p.reconnectPolicy == nil || p.psyNet == nil {
+		p.mu.Unlock()
+		_ = p.Close()
+		return
+	}
+
+	p.connected = false
+	p.stopWriter()
+	_ = conn.Close()
+	p.stopPing()
+	p.failPending()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.stopReconnect = cancel
+	policy := *p.reconnectPolicy
+	p.mu.Unlock()
+
+	p.setState(StateConnecting)
+	p.sendEvent(EventTypeReconnecting, "")
+	go p.reconnect(ctx, policy)
+}
+
+func (p *PsyNetRPC) reconnect(ctx context.Context, policy ReconnectPolicy) {
+	for attempt := 0; policy.MaxAttempts <= 0 || attempt < policy.MaxAttempts; attempt++ {
+		select {
+		case <-time.After(policy.backoff(attempt)):
+		case <-ctx.Done():
+			return
+		}
+
+		p.logger.Debug("reconnecting websocket", slog.Int("attempt", attempt+1))
+
+		conn, err := p.redial(ctx, policy.Credentials)
+		if err != nil {
+			p.logger.Warn("reconnect attempt failed", slog.Int("attempt", attempt+1), slog.Any("err", err))
+			continue
+		}
+
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		p.wsConn = conn
+		p.startWriter(conn)
+		p.connected = true
+		p.stopReconnect = nil
+		p.mu.Unlock()
+
+		p.health.connected()
+		go p.readMessages()
+		p.schedulePing()
+
+		p.setState(StateConnected)
+		p.sendEvent(EventTypeReconnected, "")
+		return
+	}
+
+	p.logger.Error("giving up on reconnect", slog.Int("attempts", policy.MaxAttempts))
+	_ = p.Close()
+}
+
+// redial reconnects with the stored session, falling back to a full AuthPlayer if the token is rejected.
+func (p *PsyNetRPC) redial(ctx context.Context, credentials CredentialSource) (*websocket.Conn, error) {
+	p.mu.Lock()
+	url, psyToken, sessionID, authReq := p.url, p.psyToken, p.sessionID, p.authReq
+	p.mu.Unlock()
+
+	conn, resp, err := p.psyNet.dialSocket(ctx, url, psyToken, sessionID)
+	if err == nil {
+		return conn, nil
+	}
+
+	rejected := resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden)
+	if !rejected || credentials == nil || authReq == nil {
+		return nil, err
+	}
+
+	p.logger.Info("psytoken rejected, re-authenticating")
+
+	ticket, err := credentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+
+	req := *authReq
+	req.AuthTicket = ticket
+	req.EpicAuthTicket = ticket
+
+	var res AuthPlayerResponse
+	if err := p.psyNet.postJSON(ctx, []string{"Auth", "AuthPlayer", "v2"}, &req, &res); err != nil {
+		return nil, fmt.Errorf("failed to authenticate player: %w", err)
+	}
+
+	p.mu.Lock()
+	p.url = p.psyNet.socketURL(res.PerConURLv2)
+	p.psyToken = res.PsyToken
+	p.sessionID = res.SessionID
+	p.authReq = &req
+	url = p.url
+	p.mu.Unlock()
+
+	conn, _, err = p.psyNet.dialSocket(ctx, url, res.PsyToken, res.SessionID)
+	return conn, err
+}
@@ -0,0 +1,344 @@
+package rlapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestReconnectPolicy_Backoff(t *testing.T) {
+	policy := ReconnectPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     1 * time.Second,
+		Jitter:         0.5,
+	}
+
+	tests := []struct {
+		attempt int
+		base    time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond},
+		{3, 800 * time.Millisecond},
+		{10, 1 * time.Second},
+	}
+	for _, tt := range tests {
+		got := policy.backoff(tt.attempt)
+		if got < tt.base/2 || got > tt.base*3/2 {
+			t.Errorf("backoff(%d) = %v, want within 50%% of %v", tt.attempt, got, tt.base)
+		}
+	}
+}
+
+func TestPsyNetRPC_Reconnect(t *testing.T) {
+	mockServer := NewMockWSServer()
+	defer mockServer.Close()
+
+	psyNet := NewPsyNet()
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-player", "test-token", "test-session")
+	if err != nil {
+		t.Fatalf("Failed to establish socket: %v", err)
+	}
+
+	rpc.SetReconnectPolicy(&ReconnectPolicy{InitialBackoff: 10 * time.Millisecond})
+	go rpc.readMessages()
+	defer rpc.Close()
+
+	// Drop the socket from underneath the reader
+	rpc.mu.Lock()
+	conn := rpc.wsConn
+	rpc.mu.Unlock()
+	conn.Close()
+
+	for _, want := range []EventType{EventTypeReconnecting, EventTypeReconnected} {
+		select {
+		case event := <-rpc.Events():
+			if event.Type != want {
+				t.Fatalf("event type = %d, want %d", event.Type, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for event %d", want)
+		}
+	}
+
+	if !rpc.IsConnected() {
+		t.Error("Expected connection to be active after reconnect")
+	}
+
+	rpc.mu.Lock()
+	replaced := rpc.wsConn != conn
+	rpc.mu.Unlock()
+	if !replaced {
+		t.Error("Expected a new websocket connection")
+	}
+}
+
+func TestPsyNetRPC_ReconnectStopsOnClose(t *testing.T) {
+	mockServer := NewMockWSServer()
+	defer mockServer.Close()
+
+	psyNet := NewPsyNet()
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-player", "test-token", "test-session")
+	if err != nil {
+		t.Fatalf("Failed to establish socket: %v", err)
+	}
+
+	rpc.SetReconnectPolicy(&ReconnectPolicy{InitialBackoff: time.Hour})
+	go rpc.readMessages()
+
+	rpc.mu.Lock()
+	rpc.wsConn.Close()
+	rpc.mu.Unlock()
+
+	select {
+	case event := <-rpc.Events():
+		if event.Type != EventTypeReconnecting {
+			t.Fatalf("event type = %d, want %d", event.Type, EventTypeReconnecting)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for reconnecting event")
+	}
+
+	rpc.Close()
+
+	select {
+	case event := <-rpc.Events():
+		if event.Type != EventTypeDisconnected {
+			t.Fatalf("event type = %d, want %d", event.Type, EventTypeDisconnected)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for disconnected event")
+	}
+
+	if rpc.IsConnected() {
+		t.Error("Expected connection to be inactive after close")
+	}
+}
+
+// reauthServer serves AuthPlayer and the WebSocket endpoint, which only accepts the latest PsyToken.
+type reauthServer struct {
+	server   *httptest.Server
+	upgrader websocket.Upgrader
+
+	mu       sync.Mutex
+	tickets  []string
+	token    string
+	rejected int
+}
+
+func newReauthServer(t *testing.T) *reauthServer {
+	s := &reauthServer{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/Auth/AuthPlayer/v2" {
+			var req AuthPlayerRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode AuthPlayer request: %v", err)
+			}
+
+			s.mu.Lock()
+			s.tickets = append(s.tickets, req.AuthTicket)
+			n := len(s.tickets)
+			s.token = fmt.Sprintf("token-%d", n)
+			s.mu.Unlock()
+
+			fmt.Fprintf(w, `{"Result":{"UseWebSocket":true,"PsyToken":"token-%d","SessionID":"session-%d","PerConURLv2":"ws://%s/ws/%d"}}`, n, n, r.Host, n)
+			return
+		}
+
+		s.mu.Lock()
+		valid := r.Header.Get("PsyToken") == s.token
+		if !valid {
+			s.rejected++
+		}
+		s.mu.Unlock()
+		if !valid {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		conn, err := s.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// revoke makes the WebSocket endpoint reject every token issued so far.
+func (s *reauthServer) revoke() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = ""
+}
+
+func (s *reauthServer) stats() (tickets []string, rejected int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.tickets...), s.rejected
+}
+
+func dropSocket(rpc *PsyNetRPC) {
+	rpc.mu.Lock()
+	defer rpc.mu.Unlock()
+	rpc.wsConn.Close()
+}
+
+func waitEvent(t *testing.T, rpc *PsyNetRPC, want EventType) {
+	t.Helper()
+	select {
+	case event := <-rpc.Events():
+		if event.Type != want {
+			t.Fatalf("event type = %d, want %d", event.Type, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for event %d", want)
+	}
+}
+
+func TestPsyNetRPC_ReconnectReauthenticates(t *testing.T) {
+	server := newReauthServer(t)
+
+	rpc, err := NewPsyNet(WithBaseURL(server.server.URL)).AuthPlayerContext(context.Background(), "ticket-1", "account", "name")
+	if err != nil {
+		t.Fatalf("AuthPlayerContext() error = %v", err)
+	}
+	defer rpc.Close()
+
+	var credentialCalls atomic.Int32
+	rpc.SetReconnectPolicy(&ReconnectPolicy{
+		InitialBackoff: 10 * time.Millisecond,
+		Credentials: func(ctx context.Context) (string, error) {
+			credentialCalls.Add(1)
+			return "ticket-2", nil
+		},
+	})
+
+	server.revoke()
+	dropSocket(rpc)
+
+	waitEvent(t, rpc, EventTypeReconnecting)
+	waitEvent(t, rpc, EventTypeReconnected)
+
+	tickets, rejected := server.stats()
+	if len(tickets) != 2 || tickets[1] != "ticket-2" {
+		t.Errorf("AuthPlayer tickets = %v, want one re-auth with ticket-2", tickets)
+	}
+	if rejected != 1 || credentialCalls.Load() != 1 {
+		t.Errorf("rejected dials = %d, credential calls = %d, want 1 each", rejected, credentialCalls.Load())
+	}
+
+	rpc.mu.Lock()
+	psyToken, sessionID, url, authTicket := rpc.psyToken, rpc.sessionID, rpc.url, rpc.authReq.AuthTicket
+	rpc.mu.Unlock()
+	if psyToken != "token-2" || sessionID != "session-2" || !strings.HasSuffix(url, "/ws/2") || authTicket != "ticket-2" {
+		t.Errorf("stored session = %s, %s, %s, %s, want the re-auth's", psyToken, sessionID, url, authTicket)
+	}
+	if !rpc.IsConnected() {
+		t.Error("Expected connection to be active after reconnect")
+	}
+}
+
+func TestPsyNetRPC_ReconnectRejectedWithoutCredentials(t *testing.T) {
+	server := newReauthServer(t)
+
+	rpc, err := NewPsyNet(WithBaseURL(server.server.URL)).AuthPlayerContext(context.Background(), "ticket-1", "account", "name")
+	if err != nil {
+		t.Fatalf("AuthPlayerContext() error = %v", err)
+	}
+	defer rpc.Close()
+
+	rpc.SetReconnectPolicy(&ReconnectPolicy{InitialBackoff: 5 * time.Millisecond, MaxAttempts: 3})
+
+	server.revoke()
+	dropSocket(rpc)
+
+	waitEvent(t, rpc, EventTypeReconnecting)
+	waitEvent(t, rpc, EventTypeDisconnected)
+
+	tickets, rejected := server.stats()
+	if len(tickets) != 1 || rejected != 3 {
+		t.Errorf("AuthPlayer calls = %d, rejected dials = %d, want 1 and 3", len(tickets), rejected)
+	}
+	if rpc.IsConnected() {
+		t.Error("Expected connection to be closed after MaxAttempts")
+	}
+}
+
+func TestPsyNetRPC_ReconnectStalePing(t *testing.T) {
+	var answer atomic.Bool
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if strings.HasPrefix(string(message), "PsyPing") && answer.Load() {
+				conn.WriteMessage(websocket.TextMessage, []byte("PsyPong: \r\n\r\n"))
+			}
+		}
+	}))
+	defer server.Close()
+
+	psyNet := NewPsyNet(WithPingInterval(50*time.Millisecond), WithPongTimeout(300*time.Millisecond))
+	rpc, err := psyNet.establishSocket(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"), "test-player", "test-token", "test-session")
+	if err != nil {
+		t.Fatalf("Failed to establish socket: %v", err)
+	}
+	rpc.SetReconnectPolicy(&ReconnectPolicy{InitialBackoff: 10 * time.Millisecond})
+	go rpc.readMessages()
+	rpc.schedulePing()
+	defer rpc.Close()
+
+	var mu sync.Mutex
+	var changes []ConnState
+	rpc.OnStateChange(func(old, new ConnState) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, new)
+	})
+
+	// the first ping goes unanswered, the socket drops while its pong is pending
+	time.Sleep(100 * time.Millisecond)
+	answer.Store(true)
+	dropSocket(rpc)
+
+	waitEvent(t, rpc, EventTypeReconnecting)
+	waitEvent(t, rpc, EventTypeReconnected)
+
+	// past the stale ping's degraded and timeout timers
+	time.Sleep(400 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, state := range changes {
+		if state == StateDegraded {
+			t.Errorf("state changes = %v, want no degraded alert for the new socket", changes)
+			break
+		}
+	}
+	if state := rpc.Health().State; state != StateConnected || !rpc.IsConnected() {
+		t.Errorf("State = %s, IsConnected = %v, want connected", state, rpc.IsConnected())
+	}
+}
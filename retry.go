@@ -0,0 +1,95 @@
+package rlapi
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetryAttempts   = 3
+	defaultRetryBackoff    = 500 * time.Millisecond
+	defaultRetryMaxBackoff = 10 * time.Second
+)
+
+// RetryPolicy configures retries of failed service calls. Zero values fall back to defaults.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first. Defaults to 3.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled after every failure. Defaults to 500ms.
+	Backoff time.Duration
+	// MaxBackoff caps the delay between retries. Defaults to 10s.
+	MaxBackoff time.Duration
+	// Idempotent reports whether a service is safe to retry. Defaults to IsIdempotentService.
+	Idempotent func(service string) bool
+	// Retryable reports whether an error should be retried. Defaults to IsRetryable.
+	Retryable func(err error) bool
+}
+
+func (r *RetryPolicy) attempts() int {
+	if r.MaxAttempts <= 0 {
+		return defaultRetryAttempts
+	}
+	return r.MaxAttempts
+}
+
+func (r *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := r.Backoff
+	if delay <= 0 {
+		delay = defaultRetryBackoff
+	}
+	maxBackoff := r.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+
+	for range attempt {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
+
+func (r *RetryPolicy) idempotent(service string) bool {
+	if r.Idempotent != nil {
+		return r.Idempotent(service)
+	}
+	return IsIdempotentService(service)
+}
+
+func (r *RetryPolicy) retryable(err error) bool {
+	if r.Retryable != nil {
+		return r.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// SetRetryPolicy enables retries of idempotent service calls, nil disables them.
+func (p *PsyNetRPC) SetRetryPolicy(policy *RetryPolicy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.retryPolicy = policy
+}
+
+// IsRetryable reports whether err is a rate limit, transport or server error that may succeed on retry.
+// A closed or missing connection is not, retrying would only hit the same dead socket.
+func IsRetryable(err error) bool {
+	if errors.Is(err, ErrConnectionClosed) || errors.Is(err, ErrNotConnected) {
+		return false
+	}
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrTransport) || errors.Is(err, ErrServer)
+}
+
+// IsIdempotentService reports whether a service only reads data, e.g. "Skills/GetPlayerSkill v1".
+func IsIdempotentService(service string) bool {
+	name := serviceBaseName(service)
+	method := name[strings.LastIndexByte(name, '/')+1:]
+	for _, prefix := range []string{"Get", "Browse", "CanShow"} {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,493 @@
+// Package rlapitest provides a scriptable fake PsyNet server for testing code built on rlapi.
+package rlapitest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dank/rlapi"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// AuthService is the service name AuthPlayer calls are recorded under.
+	AuthService = "Auth/AuthPlayer v2"
+
+	// PsyToken and SessionID are returned by the default AuthPlayer handler.
+	PsyToken  = "rlapitest-token"
+	SessionID = "rlapitest-session"
+
+	rpcPath       = "/rpc/"
+	websocketPath = "/ws"
+)
+
+// ErrDropConnection can be returned by a handler to close the WebSocket, or fail the HTTP request, instead of responding.
+var ErrDropConnection = errors.New("drop connection")
+
+// Call is a request received by the server.
+type Call struct {
+	Service   string
+	RequestID string
+	// Headers are the message headers, for HTTP calls their names are canonicalized, e.g. "Psytoken".
+	Headers map[string]string
+	Body    json.RawMessage
+	// WebSocket is true for calls received over the WebSocket, false for HTTP.
+	WebSocket bool
+}
+
+// Decode unmarshals the request body into v.
+func (c *Call) Decode(v interface{}) error {
+	return json.Unmarshal(c.Body, v)
+}
+
+// Handler answers a call with a result, or with an error. A *rlapi.PsyNetError is sent as the response's Error,
+// ErrDropConnection closes the connection, any other error is sent as a ServerError.
+type Handler func(call *Call) (interface{}, error)
+
+// Server fakes the PsyNet HTTP API and WebSocket RPC protocol, responses are signed with rlapi.DefaultResponseSigKey.
+type Server struct {
+	server *httptest.Server
+
+	upgrader websocket.Upgrader
+
+	mu        sync.Mutex
+	handlers  map[string]Handler
+	calls     []*Call
+	callCh    chan struct{}
+	conns     []*serverConn
+	connCh    chan struct{}
+	dropPongs bool
+	latency   time.Duration
+}
+
+type serverConn struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+func (c *serverConn) write(message string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, []byte(message))
+}
+
+// NewServer starts a server, AuthPlayer is answered with a WebSocket session on the same server.
+func NewServer() *Server {
+	s := &Server{
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		handlers: make(map[string]Handler),
+		callCh:   make(chan struct{}),
+		connCh:   make(chan struct{}),
+	}
+	s.handlers[AuthService] = s.authPlayer
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(rpcPath, s.handleHTTP)
+	mux.HandleFunc(websocketPath, s.handleWebSocket)
+	s.server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Close closes every connection and shuts the server down.
+func (s *Server) Close() {
+	s.DropConnections()
+	s.server.Close()
+}
+
+// URL returns the HTTP API base URL, see Options.
+func (s *Server) URL() string {
+	return s.server.URL + strings.TrimSuffix(rpcPath, "/")
+}
+
+// WebSocketURL returns the URL AuthPlayer directs clients to.
+func (s *Server) WebSocketURL() string {
+	return "ws" + strings.TrimPrefix(s.server.URL, "http") + websocketPath
+}
+
+// Options returns the options pointing a rlapi.PsyNet at the server.
+func (s *Server) Options() []rlapi.Option {
+	return []rlapi.Option{rlapi.WithBaseURL(s.URL())}
+}
+
+// Handle registers the handler for a service, e.g. "Skills/GetPlayerSkill v1".
+func (s *Server) Handle(service string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[service] = handler
+}
+
+// HandleResult answers every call to the service with result.
+func (s *Server) HandleResult(service string, result interface{}) {
+	s.Handle(service, func(call *Call) (interface{}, error) {
+		return result, nil
+	})
+}
+
+// HandleError answers every call to the service with a PsyNet error.
+func (s *Server) HandleError(service string, errorType string, message string) {
+	s.Handle(service, func(call *Call) (interface{}, error) {
+		return nil, &rlapi.PsyNetError{Type: errorType, Message: message}
+	})
+}
+
+// SetDropPongs stops the server from answering pings, letting the client's pong timeout expire.
+func (s *Server) SetDropPongs(drop bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropPongs = drop
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// Push sends a server event to every connected client. A client's dial may return before the server has
+// registered its connection, use WaitForConnection before pushing to a client that just connected.
+func (s *Server) Push(service string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal push body: %w", err)
+	}
+	psyTime := strconv.FormatInt(time.Now().Unix(), 10)
+	message := buildMessage([][2]string{
+		{"PsyService", service},
+		{"PsyTime", psyTime},
+		{"PsySig", sign(psyTime, data)},
+	}, data)
+
+	s.mu.Lock()
+	conns := slices.Clone(s.conns)
+	s.mu.Unlock()
+
+	if len(conns) == 0 {
+		return errors.New("no connected clients")
+	}
+	for _, conn := range conns {
+		if err := conn.write(message); err != nil {
+			return fmt.Errorf("failed to push message: %w", err)
+		}
+	}
+	return nil
+}
+
+// DropConnections closes every WebSocket connection without a close frame.
+func (s *Server) DropConnections() {
+	s.mu.Lock()
+	conns := s.conns
+	s.conns = nil
+	s.mu.Unlock()
+
+	for _, conn := range conns {
+		conn.conn.Close()
+	}
+}
+
+// Calls returns every call received so far, AuthPlayer included.
+func (s *Server) Calls() []*Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.calls)
+}
+
+// CallsTo returns the calls received for a service.
+func (s *Server) CallsTo(service string) []*Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var calls []*Call
+	for _, call := range s.calls {
+		if call.Service == service {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// AssertCalled fails the test unless the service was called exactly times times.
+func (s *Server) AssertCalled(t testing.TB, service string, times int) {
+	t.Helper()
+	if got := len(s.CallsTo(service)); got != times {
+		t.Errorf("rlapitest: %s called %d times, want %d", service, got, times)
+	}
+}
+
+// WaitForCall waits until the service has been called, failing the test after timeout.
+func (s *Server) WaitForCall(t testing.TB, service string, timeout time.Duration) *Call {
+	t.Helper()
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		s.mu.Lock()
+		calls := s.callCh
+		for _, call := range s.calls {
+			if call.Service == service {
+				s.mu.Unlock()
+				return call
+			}
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-calls:
+		case <-deadline.C:
+			t.Fatalf("rlapitest: timed out waiting for a call to %s", service)
+			return nil
+		}
+	}
+}
+
+// WaitForConnection waits until a WebSocket client is connected, failing the test after timeout.
+func (s *Server) WaitForConnection(t testing.TB, timeout time.Duration) {
+	t.Helper()
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		s.mu.Lock()
+		conns := s.connCh
+		connected := len(s.conns) > 0
+		s.mu.Unlock()
+		if connected {
+			return
+		}
+
+		select {
+		case <-conns:
+		case <-deadline.C:
+			t.Fatal("rlapitest: timed out waiting for a WebSocket connection")
+			return
+		}
+	}
+}
+
+func (s *Server) record(call *Call) (Handler, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, call)
+	close(s.callCh)
+	s.callCh = make(chan struct{})
+
+	return s.handlers[call.Service], s.latency
+}
+
+// dispatch runs the handler for a call and encodes the response body.
+func (s *Server) dispatch(call *Call) ([]byte, error) {
+	handler, latency := s.record(call)
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+
+	var wrapper struct {
+		Result interface{}        `json:"Result,omitempty"`
+		Error  *rlapi.PsyNetError `json:"Error,omitempty"`
+	}
+
+	if handler == nil {
+		wrapper.Error = &rlapi.PsyNetError{Type: "UnknownService", Message: call.Service}
+	} else {
+		result, err := handler(call)
+		var psyErr *rlapi.PsyNetError
+		switch {
+		case errors.Is(err, ErrDropConnection):
+			return nil, err
+		case errors.As(err, &psyErr):
+			wrapper.Error = psyErr
+		case err != nil:
+			wrapper.Error = &rlapi.PsyNetError{Type: "ServerError", Message: err.Error()}
+		default:
+			wrapper.Result = result
+		}
+	}
+
+	return json.Marshal(wrapper)
+}
+
+func (s *Server) authPlayer(call *Call) (interface{}, error) {
+	var req rlapi.AuthPlayerRequest
+	if err := call.Decode(&req); err != nil {
+		return nil, &rlapi.PsyNetError{Type: "InvalidParameters", Message: err.Error()}
+	}
+
+	return &rlapi.AuthPlayerResponse{
+		SessionID:          SessionID,
+		VerifiedPlayerName: req.PlayerName,
+		UseWebSocket:       true,
+		PerConURL:          s.WebSocketURL(),
+		PerConURLv2:        s.WebSocketURL(),
+		PsyToken:           PsyToken,
+	}, nil
+}
+
+func (s *Server) handleHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	headers := make(map[string]string, len(r.Header))
+	for key := range r.Header {
+		headers[key] = r.Header.Get(key)
+	}
+
+	resp, err := s.dispatch(&Call{
+		Service:   pathService(strings.TrimPrefix(r.URL.Path, rpcPath)),
+		RequestID: r.Header.Get("PsyRequestID"),
+		Headers:   headers,
+		Body:      body,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	psyTime := strconv.FormatInt(time.Now().Unix(), 10)
+	w.Header().Set("PsyTime", psyTime)
+	w.Header().Set("PsySig", sign(psyTime, resp))
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
+
+func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("PsyToken") != PsyToken {
+		http.Error(w, "invalid PsyToken", http.StatusUnauthorized)
+		return
+	}
+
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	conn := &serverConn{conn: ws}
+
+	s.mu.Lock()
+	s.conns = append(s.conns, conn)
+	close(s.connCh)
+	s.connCh = make(chan struct{})
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.conns = slices.DeleteFunc(s.conns, func(c *serverConn) bool { return c == conn })
+		s.mu.Unlock()
+		ws.Close()
+	}()
+
+	for {
+		_, message, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		headers, body, ok := splitMessage(string(message))
+		if !ok {
+			continue
+		}
+
+		if _, ok := headers["PsyPing"]; ok {
+			s.mu.Lock()
+			drop := s.dropPongs
+			s.mu.Unlock()
+			if !drop {
+				conn.write(buildMessage([][2]string{{"PsyPong", ""}}, nil))
+			}
+			continue
+		}
+
+		call := &Call{
+			Service:   headers["PsyService"],
+			RequestID: headers["PsyRequestID"],
+			Headers:   headers,
+			Body:      json.RawMessage(body),
+			WebSocket: true,
+		}
+
+		// respond concurrently so latency and slow handlers don't block other calls
+		go func() {
+			resp, err := s.dispatch(call)
+			if err != nil {
+				ws.Close()
+				return
+			}
+
+			psyTime := strconv.FormatInt(time.Now().Unix(), 10)
+			conn.write(buildMessage([][2]string{
+				{"PsyResponseID", call.RequestID},
+				{"PsyTime", psyTime},
+				{"PsySig", sign(psyTime, resp)},
+			}, resp))
+		}()
+	}
+}
+
+// pathService converts an HTTP path to a service name, "Skills/GetPlayerSkill/v1" becomes "Skills/GetPlayerSkill v1".
+func pathService(path string) string {
+	idx := strings.LastIndexByte(path, '/')
+	if idx == -1 {
+		return path
+	}
+	if version := path[idx+1:]; strings.HasPrefix(version, "v") {
+		if _, err := strconv.Atoi(version[1:]); err == nil {
+			return path[:idx] + " " + version
+		}
+	}
+	return path
+}
+
+// sign computes a response signature the way PsyNet does, see rlapi.DefaultResponseSigKey.
+func sign(psyTime string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(rlapi.DefaultResponseSigKey))
+	h.Write([]byte(psyTime))
+	h.Write([]byte("-"))
+	h.Write(body)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func buildMessage(headers [][2]string, body []byte) string {
+	var message strings.Builder
+	for _, header := range headers {
+		message.WriteString(header[0])
+		message.WriteString(": ")
+		message.WriteString(header[1])
+		message.WriteString("\r\n")
+	}
+	message.WriteString("\r\n")
+	message.Write(body)
+	return message.String()
+}
+
+func splitMessage(message string) (map[string]string, string, bool) {
+	head, body, ok := strings.Cut(message, "\r\n\r\n")
+	if !ok {
+		return nil, "", false
+	}
+
+	headers := make(map[string]string)
+	for _, line := range strings.Split(head, "\r\n") {
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return headers, body, true
+}
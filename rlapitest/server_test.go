@@ -0,0 +1,141 @@
+package rlapitest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dank/rlapi"
+)
+
+func newClient(t *testing.T, server *Server, opts ...rlapi.Option) *rlapi.PsyNetRPC {
+	t.Helper()
+
+	psyNet := rlapi.NewPsyNet(append(server.Options(), opts...)...)
+	psyNet.SetResponseVerificationKey(rlapi.DefaultResponseSigKey)
+
+	rpc, err := psyNet.AuthPlayerContext(context.Background(), "test-ticket", "test-account", "test-player")
+	if err != nil {
+		t.Fatalf("AuthPlayer() error = %v", err)
+	}
+	t.Cleanup(func() { rpc.Close() })
+	return rpc
+}
+
+func TestServer_Calls(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.HandleResult("Challenges/GetActiveChallenges v1", map[string]interface{}{
+		"Challenges": []map[string]interface{}{{"ID": 1, "Title": "Win a match"}},
+	})
+	server.HandleError("Skills/GetPlayerSkill v1", "PlayerBanned", "banned")
+
+	rpc := newClient(t, server)
+
+	challenges, err := rpc.GetActiveChallenges(context.Background())
+	if err != nil {
+		t.Fatalf("GetActiveChallenges() error = %v", err)
+	}
+	if len(challenges) != 1 || challenges[0].Title != "Win a match" {
+		t.Errorf("challenges = %+v", challenges)
+	}
+
+	var psyErr *rlapi.PsyNetError
+	err = rpc.Call(context.Background(), "Skills/GetPlayerSkill", 1, nil, nil)
+	if !errors.As(err, &psyErr) || psyErr.Type != "PlayerBanned" {
+		t.Errorf("Call() error = %v, want PlayerBanned", err)
+	}
+
+	err = rpc.Call(context.Background(), "Missing/Service", 1, nil, nil)
+	if !errors.As(err, &psyErr) || psyErr.Type != "UnknownService" {
+		t.Errorf("Call() error = %v, want UnknownService", err)
+	}
+
+	server.AssertCalled(t, AuthService, 1)
+	server.AssertCalled(t, "Challenges/GetActiveChallenges v1", 1)
+
+	auth := server.CallsTo(AuthService)[0]
+	var req rlapi.AuthPlayerRequest
+	if err := auth.Decode(&req); err != nil || req.AuthTicket != "test-ticket" {
+		t.Errorf("AuthPlayer request = %+v, err = %v", req, err)
+	}
+}
+
+func TestServer_HTTPTransport(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.HandleResult("Challenges/GetActiveChallenges v1", map[string]interface{}{"Challenges": []interface{}{}})
+
+	psyNet := rlapi.NewPsyNet(server.Options()...)
+	psyNet.SetTransportMode(rlapi.TransportHTTP)
+	rpc, err := psyNet.AuthPlayerContext(context.Background(), "test-ticket", "test-account", "test-player")
+	if err != nil {
+		t.Fatalf("AuthPlayer() error = %v", err)
+	}
+	defer rpc.Close()
+
+	if _, err := rpc.GetActiveChallenges(context.Background()); err != nil {
+		t.Fatalf("GetActiveChallenges() error = %v", err)
+	}
+
+	call := server.CallsTo("Challenges/GetActiveChallenges v1")[0]
+	if call.WebSocket || call.Headers["Psytoken"] != PsyToken {
+		t.Errorf("call = %+v, want HTTP call with PsyToken", call)
+	}
+}
+
+func TestServer_Push(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	rpc := newClient(t, server)
+	sub := rpc.Subscribe(rlapi.SubscribeOptions{Services: []string{rlapi.PushPartySystem}})
+	defer sub.Close()
+
+	server.WaitForConnection(t, time.Second)
+	if err := server.Push(rlapi.PushPartySystem, map[string]string{"Message": "hi"}); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	select {
+	case event := <-sub.Events():
+		if event.Service != rlapi.PushPartySystem {
+			t.Errorf("event service = %s, want %s", event.Service, rlapi.PushPartySystem)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for push")
+	}
+}
+
+func TestServer_LatencyAndDrop(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.SetLatency(50 * time.Millisecond)
+	server.HandleResult("Challenges/GetActiveChallenges v1", map[string]interface{}{"Challenges": []interface{}{}})
+	server.Handle("Products/TradeIn v2", func(call *Call) (interface{}, error) {
+		return nil, ErrDropConnection
+	})
+
+	rpc := newClient(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := rpc.GetActiveChallenges(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetActiveChallenges() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	go rpc.Call(context.Background(), "Products/TradeIn", 2, nil, nil)
+	server.WaitForCall(t, "Products/TradeIn v2", time.Second)
+
+	deadline := time.Now().Add(time.Second)
+	for rpc.IsConnected() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if rpc.IsConnected() {
+		t.Error("Expected client to be disconnected after the server dropped the connection")
+	}
+}
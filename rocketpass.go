@@ -1,6 +1,9 @@
 package rlapi
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 // RocketPassInfo represents information about a player's Rocket Pass progress
 type RocketPassInfo struct {
@@ -62,9 +65,9 @@ type CurrencyDrop struct {
 
 // PrestigeReward represents a prestige reward in Rocket Pass
 type PrestigeReward struct {
-	Level       int           `json:"Level"`
-	ProductData []Product     `json:"ProductData"`
-	Currency    []interface{} `json:"Currency"`
+	Level       int               `json:"Level"`
+	ProductData []Product         `json:"ProductData"`
+	Currency    []json.RawMessage `json:"Currency"`
 }
 
 type GetPlayerInfoRequest struct {
@@ -79,6 +82,8 @@ type GetPlayerInfoResponse struct {
 	EndTime         int             `json:"EndTime"`
 	RocketPassInfo  RocketPassInfo  `json:"RocketPassInfo"`
 	RocketPassStore RocketPassStore `json:"RocketPassStore"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetRewardContentRequest struct {
@@ -94,6 +99,8 @@ type GetRewardContentResponse struct {
 	PremiumMaxLevel int                `json:"PremiumMaxLevel"`
 	FreeRewards     []RocketPassReward `json:"FreeRewards"`
 	PremiumRewards  []RocketPassReward `json:"PremiumRewards"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetPlayerPrestigeRewardsRequest struct {
@@ -103,6 +110,8 @@ type GetPlayerPrestigeRewardsRequest struct {
 
 type GetPlayerPrestigeRewardsResponse struct {
 	PrestigeRewards []PrestigeReward `json:"PrestigeRewards"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // GetRocketPassPlayerInfo retrieves Rocket Pass information for the authenticated player.
@@ -1,6 +1,9 @@
 package rlapi
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 type ShopID int
 
@@ -34,7 +37,7 @@ type ShopItem struct {
 	ShopItemLocations      []int                 `json:"ShopItemLocations"`
 	Title                  *string               `json:"Title"`
 	Description            *string               `json:"Description"`
-	FeaturedCollections    []interface{}         `json:"FeaturedCollections"`
+	FeaturedCollections    []json.RawMessage     `json:"FeaturedCollections"`
 	Attributes             []ProductAttribute    `json:"Attributes"`
 	Disclaimer             *string               `json:"Disclaimer"`
 	PurchasedQuantity      int                   `json:"PurchasedQuantity"`
@@ -69,22 +72,22 @@ type Product struct {
 
 // ProductAttribute represents an attribute of a product
 type ProductAttribute struct {
-	Key   string      `json:"Key"`
-	Value interface{} `json:"Value"`
+	Key   string          `json:"Key"`
+	Value json.RawMessage `json:"Value"`
 }
 
 // ShopItemCost represents the cost of a shop item
 type ShopItemCost struct {
 	ResetTime      *int64          `json:"ResetTime"`
 	ShopItemCostID int             `json:"ShopItemCostID"`
-	Discount       *interface{}    `json:"Discount"`
-	BulkDiscounts  *interface{}    `json:"BulkDiscounts"`
+	Discount       json.RawMessage `json:"Discount"`
+	BulkDiscounts  json.RawMessage `json:"BulkDiscounts"`
 	StartDate      int64           `json:"StartDate"`
 	EndDate        *int64          `json:"EndDate"`
 	Price          []CurrencyPrice `json:"Price"`
 	SortID         int             `json:"SortID"`
 	DisplayTypeID  int             `json:"DisplayTypeID"`
-	ShopScaledCost interface{}     `json:"ShopScaledCost"`
+	ShopScaledCost json.RawMessage `json:"ShopScaledCost"`
 }
 
 // CurrencyPrice represents a price in the specified currency
@@ -95,6 +98,8 @@ type CurrencyPrice struct {
 
 type GetStandardShopsResponse struct {
 	Shops []Shop `json:"Shops"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetShopCatalogueRequest struct {
@@ -103,6 +108,8 @@ type GetShopCatalogueRequest struct {
 
 type GetShopCatalogueResponse struct {
 	Catalogues []ShopCatalogue `json:"Catalogues"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetPlayerWalletRequest struct {
@@ -118,6 +125,8 @@ type GetPlayerWalletResponse struct {
 		IsTradable       bool    `json:"IsTradable"`
 		TradeHold        *string `json:"TradeHold"`
 	} `json:"Currencies"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetShopNotificationsResponse struct {
@@ -130,6 +139,8 @@ type GetShopNotificationsResponse struct {
 		Title               string               `json:"Title"`
 		DeliverableProducts []DeliverableProduct `json:"DeliverableProducts"`
 	} `json:"ShopNotifications"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // GetStandardShops retrieves the list of available shops.
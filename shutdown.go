@@ -0,0 +1,63 @@
+package rlapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrShuttingDown is returned for calls made after Shutdown started.
+var ErrShuttingDown = errors.New("connection is shutting down")
+
+// ShutdownError is returned by Shutdown when the context expired before every in-flight request completed.
+type ShutdownError struct {
+	Abandoned []InFlightRequest
+	Err       error
+}
+
+func (e *ShutdownError) Error() string {
+	return fmt.Sprintf("shutdown abandoned %d in-flight requests: %v", len(e.Abandoned), e.Err)
+}
+
+func (e *ShutdownError) Unwrap() error {
+	return e.Err
+}
+
+// Shutdown stops accepting new calls, waits for in-flight requests to complete and closes the connection.
+// If ctx expires first the remaining requests are abandoned, their callers get ErrConnectionClosed,
+// and a *ShutdownError listing them is returned.
+func (p *PsyNetRPC) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+
+	p.draining = true
+	var drained chan struct{}
+	if len(p.pendingReqs) > 0 {
+		if p.drained == nil {
+			p.drained = make(chan struct{})
+		}
+		drained = p.drained
+	}
+	p.mu.Unlock()
+
+	if drained == nil {
+		return p.Close()
+	}
+
+	select {
+	case <-drained:
+		return p.Close()
+	case <-ctx.Done():
+	}
+
+	abandoned := p.inFlight()
+	closeErr := p.Close()
+
+	if len(abandoned) == 0 {
+		return closeErr
+	}
+	return &ShutdownError{Abandoned: abandoned, Err: ctx.Err()}
+}
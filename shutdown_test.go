@@ -0,0 +1,148 @@
+package rlapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestPsyNetRPC_ShutdownDrains(t *testing.T) {
+	// the server holds every request until release is closed, then answers it
+	requests := make(chan string, 1)
+	release := make(chan struct{})
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			requestID, err := parseTestRequest(message)
+			if err != nil {
+				continue
+			}
+			requests <- requestID
+			<-release
+			conn.WriteMessage(websocket.TextMessage, []byte("PsyResponseID: "+requestID+"\r\n\r\n"+`{"Result":{"Done":true}}`))
+		}
+	}))
+	defer server.Close()
+
+	psyNet := NewPsyNet()
+	rpc, err := psyNet.establishSocket(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"), "test-player", "test-token", "test-session")
+	if err != nil {
+		t.Fatalf("Failed to establish socket: %v", err)
+	}
+	go rpc.readMessages()
+
+	respCh, err := rpc.sendRequestAsync(context.Background(), "Test/Pending v1", map[string]string{})
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	<-requests
+
+	done := make(chan error, 1)
+	go func() {
+		done <- rpc.Shutdown(context.Background())
+	}()
+
+	// Wait for Shutdown to start draining
+	for {
+		rpc.mu.Lock()
+		draining := rpc.draining
+		rpc.mu.Unlock()
+		if draining {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := rpc.sendRequestAsync(context.Background(), "Test/Rejected v1", map[string]string{}); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("err = %v, want ErrShuttingDown", err)
+	}
+
+	select {
+	case err := <-done:
+		t.Fatalf("Shutdown returned %v before the request completed", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+
+	var result struct{ Done bool }
+	if err := rpc.awaitResponse(context.Background(), respCh, &result); err != nil || !result.Done {
+		t.Errorf("in-flight request = %+v, %v, want its response", result, err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Shutdown() = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Shutdown did not return after the request completed")
+	}
+
+	if rpc.IsConnected() {
+		t.Error("Expected connection to be closed after shutdown")
+	}
+}
+
+// parseTestRequest returns the PsyRequestID of a request written by the client.
+func parseTestRequest(message []byte) (string, error) {
+	headers, _, err := splitMessage(string(message))
+	if err != nil {
+		return "", err
+	}
+	requestID, ok := headers["PsyRequestID"]
+	if !ok {
+		return "", errors.New("missing PsyRequestID")
+	}
+	return requestID, nil
+}
+
+func TestPsyNetRPC_ShutdownAbandons(t *testing.T) {
+	mockServer := NewMockWSServer()
+	defer mockServer.Close()
+
+	psyNet := NewPsyNet()
+	rpc, err := psyNet.establishSocket(context.Background(), mockServer.URL(), "test-player", "test-token", "test-session")
+	if err != nil {
+		t.Fatalf("Failed to establish socket: %v", err)
+	}
+	go rpc.readMessages()
+
+	if _, err := rpc.sendRequestAsync(context.Background(), "Products/TradeIn v2", map[string]string{}); err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = rpc.Shutdown(ctx)
+	var shutdownErr *ShutdownError
+	if !errors.As(err, &shutdownErr) {
+		t.Fatalf("Shutdown() = %v, want *ShutdownError", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if len(shutdownErr.Abandoned) != 1 || shutdownErr.Abandoned[0].Service != "Products/TradeIn v2" {
+		t.Errorf("Abandoned = %+v, want one Products/TradeIn v2 request", shutdownErr.Abandoned)
+	}
+	if rpc.IsConnected() {
+		t.Error("Expected connection to be closed after shutdown")
+	}
+}
@@ -0,0 +1,51 @@
+package rlapi
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// DefaultResponseSigKey is the key PsyNet signs its responses with, reverse engineered from the game binary.
+const DefaultResponseSigKey = "3b932153785842ac927744b292e40e52"
+
+// ErrSignatureMismatch matches every SignatureError.
+var ErrSignatureMismatch = errors.New("response signature mismatch")
+
+// SignatureError is returned when a response's PsySig doesn't match its PsyTime and body.
+type SignatureError struct {
+	Service   string
+	RequestID string
+	PsyTime   string
+	PsySig    string
+}
+
+func (e *SignatureError) Error() string {
+	return fmt.Sprintf("response signature mismatch for service: %s, request: %s", e.Service, e.RequestID)
+}
+
+func (e *SignatureError) Is(target error) bool {
+	return target == ErrSignatureMismatch
+}
+
+// SetResponseVerificationKey enables PsySig verification of HTTP and WebSocket responses with the given key,
+// usually DefaultResponseSigKey. An empty key disables verification. Connections inherit the key from AuthPlayer.
+func (p *PsyNet) SetResponseVerificationKey(key string) {
+	p.responseSigKey = key
+}
+
+// verifyPsySig checks a response signature, computed as HMAC-SHA256(key, PsyTime + "-" + body).
+func verifyPsySig(key string, psyTime string, psySig string, body []byte) bool {
+	h := hmac.New(sha256.New, []byte(key))
+	h.Write([]byte(psyTime))
+	h.Write([]byte("-"))
+	h.Write(body)
+
+	sig, err := base64.StdEncoding.DecodeString(psySig)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(sig, h.Sum(nil))
+}
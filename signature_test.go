@@ -0,0 +1,62 @@
+package rlapi
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func signResponse(psyTime string, body string) string {
+	h := hmac.New(sha256.New, []byte(DefaultResponseSigKey))
+	h.Write([]byte(psyTime + "-" + body))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func TestVerifyPsySig(t *testing.T) {
+	body := `{"Result":{"Message":"ok"}}`
+	sig := signResponse("1700000000", body)
+
+	if !verifyPsySig(DefaultResponseSigKey, "1700000000", sig, []byte(body)) {
+		t.Error("expected valid signature to verify")
+	}
+	if verifyPsySig(DefaultResponseSigKey, "1700000001", sig, []byte(body)) {
+		t.Error("expected signature with wrong PsyTime to fail")
+	}
+	if verifyPsySig(DefaultResponseSigKey, "1700000000", sig, []byte(body+" ")) {
+		t.Error("expected signature with tampered body to fail")
+	}
+	if verifyPsySig(DefaultResponseSigKey, "1700000000", "not base64!", []byte(body)) {
+		t.Error("expected malformed signature to fail")
+	}
+}
+
+func TestPsyNetRPC_ParseMessageSignature(t *testing.T) {
+	rpc := &PsyNetRPC{responseSigKey: DefaultResponseSigKey}
+	body := `{"Result":{"Message":"ok"}}`
+
+	valid := fmt.Sprintf("PsyTime: 1700000000\r\nPsySig: %s\r\nPsyResponseID: PsyNetMessage_X_1\r\n\r\n%s", signResponse("1700000000", body), body)
+	resp, err := rpc.parseMessage([]byte(valid))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.err != nil {
+		t.Errorf("unexpected signature error: %v", resp.err)
+	}
+
+	tampered := fmt.Sprintf("PsyTime: 1700000000\r\nPsySig: %s\r\nPsyResponseID: PsyNetMessage_X_1\r\n\r\n%s", signResponse("1700000000", body), `{"Result":{"Message":"no"}}`)
+	resp, err = rpc.parseMessage([]byte(tampered))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var sigErr *SignatureError
+	if !errors.As(resp.err, &sigErr) || !errors.Is(resp.err, ErrSignatureMismatch) {
+		t.Fatalf("err = %v, want SignatureError", resp.err)
+	}
+	if sigErr.RequestID != "PsyNetMessage_X_1" {
+		t.Errorf("RequestID = %q, want %q", sigErr.RequestID, "PsyNetMessage_X_1")
+	}
+}
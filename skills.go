@@ -1,6 +1,9 @@
 package rlapi
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 // Skill represents a player's skill data for the specified playlist
 type Skill struct {
@@ -53,6 +56,8 @@ type GetPlayerSkillRequest struct {
 type GetPlayerSkillResponse struct {
 	Skills       []Skill      `json:"Skills"`
 	RewardLevels RewardLevels `json:"RewardLevels"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetSkillLeaderboardRequest struct {
@@ -63,6 +68,8 @@ type GetSkillLeaderboardRequest struct {
 type GetSkillLeaderboardResponse struct {
 	LeaderboardID string                `json:"LeaderboardID"`
 	Platforms     []PlatformLeaderboard `json:"Platforms"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetSkillLeaderboardValueForUserRequest struct {
@@ -75,6 +82,8 @@ type GetSkillLeaderboardValueForUserResponse struct {
 	HasSkill      bool    `json:"bHasSkill"`
 	MMR           float64 `json:"MMR"`
 	Value         int     `json:"Value"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetSkillLeaderboardRankForUsersRequest struct {
@@ -85,6 +94,8 @@ type GetSkillLeaderboardRankForUsersRequest struct {
 type GetSkillLeaderboardRankForUsersResponse struct {
 	LeaderboardID string                  `json:"LeaderboardID"`
 	Players       []LeaderboardRankPlayer `json:"Players"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetPlayersSkillsRequest struct {
@@ -93,6 +104,8 @@ type GetPlayersSkillsRequest struct {
 
 type GetPlayersSkillsResponse struct {
 	Players []PlayerWithSkills `json:"Players"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // GetPlayerSkill retrieves skill data for a given player.
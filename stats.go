@@ -1,6 +1,9 @@
 package rlapi
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 // StatPlatformLeaderboard represents leaderboard data for a given platform
 type StatPlatformLeaderboard struct {
@@ -31,6 +34,8 @@ type GetStatLeaderboardRequest struct {
 type GetStatLeaderboardResponse struct {
 	LeaderboardID string                    `json:"LeaderboardID"`
 	Platforms     []StatPlatformLeaderboard `json:"Platforms"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetStatLeaderboardValueForUserRequest struct {
@@ -43,6 +48,8 @@ type GetStatLeaderboardValueForUserResponse struct {
 	HasStat       bool   `json:"bHasStat"`
 	Value         string `json:"Value"`
 	Rank          int    `json:"Rank"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetStatLeaderboardRankForUsersRequest struct {
@@ -53,6 +60,8 @@ type GetStatLeaderboardRankForUsersRequest struct {
 type GetStatLeaderboardRankForUsersResponse struct {
 	LeaderboardID string                      `json:"LeaderboardID"`
 	Players       []StatLeaderboardRankPlayer `json:"Players"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // GetStatLeaderboard retrieves the stats leaderboard for a given stat.
@@ -0,0 +1,343 @@
+package rlapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// SchemaIssueKind tells unknown fields and type mismatches apart.
+type SchemaIssueKind string
+
+const (
+	// SchemaUnknownField is a field in the response without a matching struct field.
+	SchemaUnknownField SchemaIssueKind = "unknown field"
+	// SchemaTypeMismatch is a value whose JSON type doesn't fit the struct field.
+	SchemaTypeMismatch SchemaIssueKind = "type mismatch"
+)
+
+// SchemaIssue is a difference between a response and the type it is decoded into.
+type SchemaIssue struct {
+	Service string
+	Kind    SchemaIssueKind
+	// Path locates the value in the result, e.g. "$.Tournaments[].Title".
+	Path string
+	// Expected is the Go type, Actual the JSON type found. Both are empty for unknown fields.
+	Expected string
+	Actual   string
+}
+
+func (i SchemaIssue) String() string {
+	if i.Kind == SchemaTypeMismatch {
+		return fmt.Sprintf("%s: %s at %s: expected %s, got %s", i.Service, i.Kind, i.Path, i.Expected, i.Actual)
+	}
+	return fmt.Sprintf("%s: %s at %s", i.Service, i.Kind, i.Path)
+}
+
+// SchemaIssueCount is a distinct issue with the number of responses it was seen in.
+type SchemaIssueCount struct {
+	SchemaIssue
+	Count int
+}
+
+// StrictDecoder checks every decoded result for schema drift, see StrictDecoder.Interceptor.
+// Issues are logged once per service and path, results are still decoded as usual.
+type StrictDecoder struct {
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	onIssue []func(SchemaIssue)
+	seen    map[SchemaIssue]int
+}
+
+// NewStrictDecoder creates a strict decoder, add it to a client with PsyNet.Use or PsyNetRPC.Use.
+func NewStrictDecoder() *StrictDecoder {
+	return &StrictDecoder{
+		logger: slog.Default(),
+		seen:   make(map[SchemaIssue]int),
+	}
+}
+
+func (s *StrictDecoder) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+}
+
+// OnIssue registers a callback called for every issue found, including repeats.
+func (s *StrictDecoder) OnIssue(callback func(SchemaIssue)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onIssue = append(s.onIssue, callback)
+}
+
+// Interceptor decodes each result strictly, it must be added after interceptors that replace the result, e.g. a cache.
+func (s *StrictDecoder) Interceptor() Interceptor {
+	return func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+		rv := reflect.ValueOf(result)
+		if rv.Kind() != reflect.Pointer || rv.IsNil() {
+			return next(ctx, info, result)
+		}
+
+		var raw json.RawMessage
+		if err := next(ctx, info, &raw); err != nil {
+			return err
+		}
+
+		// responses without a Result, e.g. from services returning nothing, leave result untouched
+		if len(raw) == 0 {
+			return nil
+		}
+
+		s.Check(info.Service, raw, result)
+
+		if err := json.Unmarshal(raw, result); err != nil {
+			return fmt.Errorf("failed to unmarshal result: %w", err)
+		}
+		return nil
+	}
+}
+
+// Check reports the differences between data and the type of v, which must be a pointer. It doesn't decode data.
+func (s *StrictDecoder) Check(service string, data []byte, v interface{}) []SchemaIssue {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil
+	}
+
+	var issues []SchemaIssue
+	checkSchema(&issues, service, "$", value, reflect.TypeOf(v).Elem())
+
+	for _, issue := range issues {
+		s.report(issue)
+	}
+	return issues
+}
+
+func (s *StrictDecoder) report(issue SchemaIssue) {
+	s.mu.Lock()
+	s.seen[issue]++
+	first := s.seen[issue] == 1
+	callbacks := s.onIssue
+	s.mu.Unlock()
+
+	if first {
+		s.logger.Warn("response schema drift",
+			slog.String("service", issue.Service),
+			slog.String("kind", string(issue.Kind)),
+			slog.String("path", issue.Path),
+			slog.String("expected", issue.Expected),
+			slog.String("actual", issue.Actual))
+	}
+	for _, callback := range callbacks {
+		callback(issue)
+	}
+}
+
+// Summary returns every distinct issue seen, sorted by service and path.
+func (s *StrictDecoder) Summary() []SchemaIssueCount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	summary := make([]SchemaIssueCount, 0, len(s.seen))
+	for issue, count := range s.seen {
+		summary = append(summary, SchemaIssueCount{SchemaIssue: issue, Count: count})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		a, b := summary[i], summary[j]
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Kind < b.Kind
+	})
+	return summary
+}
+
+// WriteSummary writes the summary as one line per issue, e.g. for a CI log.
+func (s *StrictDecoder) WriteSummary(w io.Writer) error {
+	summary := s.Summary()
+	if len(summary) == 0 {
+		_, err := fmt.Fprintln(w, "no schema drift")
+		return err
+	}
+	for _, issue := range summary {
+		if _, err := fmt.Fprintf(w, "%s (seen %d times)\n", issue, issue.Count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSchema walks a decoded JSON value alongside the Go type it is decoded into.
+func checkSchema(issues *[]SchemaIssue, service string, path string, value interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if value == nil || t.Kind() == reflect.Interface {
+		return
+	}
+	// types keeping unknown members in Extra are still checked, Extra is skipped as it is tagged "-"
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) && !hasExtraField(t) {
+		return
+	}
+
+	mismatch := func() {
+		*issues = append(*issues, SchemaIssue{
+			Service:  service,
+			Kind:     SchemaTypeMismatch,
+			Path:     path,
+			Expected: t.String(),
+			Actual:   jsonKind(value),
+		})
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			mismatch()
+			return
+		}
+		fields := structFields(t)
+		for key, field := range obj {
+			fieldType, ok := lookupField(fields, key)
+			if !ok {
+				*issues = append(*issues, SchemaIssue{
+					Service: service,
+					Kind:    SchemaUnknownField,
+					Path:    path + "." + key,
+				})
+				continue
+			}
+			checkSchema(issues, service, path+"."+key, field, fieldType)
+		}
+	case reflect.Map:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			mismatch()
+			return
+		}
+		for _, item := range obj {
+			checkSchema(issues, service, path+".*", item, t.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if _, ok := value.(string); !ok {
+				mismatch()
+			}
+			return
+		}
+		arr, ok := value.([]interface{})
+		if !ok {
+			mismatch()
+			return
+		}
+		for _, item := range arr {
+			checkSchema(issues, service, path+"[]", item, t.Elem())
+		}
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			mismatch()
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			mismatch()
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := value.(json.Number)
+		if !ok || strings.ContainsAny(n.String(), ".eE") {
+			mismatch()
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := value.(json.Number); !ok {
+			mismatch()
+		}
+	}
+}
+
+// structFields maps the JSON names of a struct's fields to their types, following encoding/json's rules for tags and embedding.
+func structFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for embeddedName, embeddedType := range structFields(embedded) {
+					if _, ok := fields[embeddedName]; !ok {
+						fields[embeddedName] = embeddedType
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// hasExtraField reports whether t is a response struct keeping unknown members, see unmarshalExtra.
+func hasExtraField(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	field, ok := t.FieldByName("Extra")
+	return ok && field.Type == reflect.TypeOf(map[string]json.RawMessage(nil))
+}
+
+// lookupField finds a field by JSON name, case-insensitively like encoding/json.
+func lookupField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if t, ok := fields[key]; ok {
+		return t, true
+	}
+	for name, t := range fields {
+		if strings.EqualFold(name, key) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	}
+	return "null"
+}
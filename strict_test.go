@@ -0,0 +1,113 @@
+package rlapi
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestStrictDecoder_Check(t *testing.T) {
+	type base struct {
+		ID int `json:"ID"`
+	}
+	type item struct {
+		base
+		Name   string             `json:"Name"`
+		Price  float64            `json:"Price"`
+		Tags   map[string]int     `json:"Tags"`
+		Extra  *string            `json:"Extra"`
+		Hidden string             `json:"-"`
+		Raw    []byte             `json:"Raw"`
+		Any    interface{}        `json:"Any"`
+		Nested map[string][]*base `json:"Nested"`
+	}
+	type response struct {
+		Items []item `json:"Items"`
+	}
+
+	data := `{"Items":[{"id":1,"Name":"Octane","Price":1.5,"Tags":{"a":1,"b":"x"},"Extra":null,"Raw":"AQ==","Any":[1],
+		"Nested":{"k":[{"ID":2.5}]},"Hidden":"h","Rarity":"Import"}]}`
+
+	decoder := NewStrictDecoder()
+	var got []SchemaIssue
+	decoder.OnIssue(func(issue SchemaIssue) { got = append(got, issue) })
+
+	issues := decoder.Check("Shops/GetStandardShops v1", []byte(data), &response{})
+	if len(issues) != len(got) {
+		t.Errorf("callback saw %d issues, Check returned %d", len(got), len(issues))
+	}
+
+	want := map[string]SchemaIssueKind{
+		"$.Items[].Tags.*":        SchemaTypeMismatch,
+		"$.Items[].Nested.*[].ID": SchemaTypeMismatch,
+		"$.Items[].Hidden":        SchemaUnknownField,
+		"$.Items[].Rarity":        SchemaUnknownField,
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("issues = %v, want %d", issues, len(want))
+	}
+	for _, issue := range issues {
+		if want[issue.Path] != issue.Kind {
+			t.Errorf("unexpected issue %s", issue)
+		}
+	}
+}
+
+func TestStrictDecoder_Interceptor(t *testing.T) {
+	rpc := NewPsyNet().newHTTPRPC("test-player", "test-token", "test-session")
+	rpc.transport = &stubTransport{results: map[string]string{
+		"Playlists/GetActivePlaylists v1": `{"CasualPlaylists":[{"NodeID":"OnesCasual","Playlist":1,"Type":"1","Region":"EU"}],"XPLevelUnlocked":20}`,
+	}}
+
+	decoder := NewStrictDecoder()
+	rpc.Use(decoder.Interceptor())
+
+	for range 2 {
+		if _, err := rpc.GetActivePlaylists(context.Background()); err == nil {
+			t.Fatal("Expected the type mismatch to fail decoding")
+		}
+	}
+
+	summary := decoder.Summary()
+	if len(summary) != 2 {
+		t.Fatalf("summary = %+v, want 2 issues", summary)
+	}
+	if summary[0].Path != "$.CasualPlaylists[].Region" || summary[0].Count != 2 {
+		t.Errorf("summary[0] = %+v", summary[0])
+	}
+	if summary[1].Path != "$.CasualPlaylists[].Type" || summary[1].Expected != "int" || summary[1].Actual != "string" {
+		t.Errorf("summary[1] = %+v", summary[1])
+	}
+
+	var buf bytes.Buffer
+	if err := decoder.WriteSummary(&buf); err != nil {
+		t.Fatalf("WriteSummary() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Playlists/GetActivePlaylists v1: unknown field at $.CasualPlaylists[].Region (seen 2 times)") {
+		t.Errorf("WriteSummary() = %s", buf.String())
+	}
+}
+
+func TestStrictDecoder_EmptyResult(t *testing.T) {
+	rpc := newPsyNetRPC(nil, "test-player", NewPsyNet())
+	decoder := NewStrictDecoder()
+	rpc.Use(decoder.Interceptor())
+	rpc.Use(func(ctx context.Context, info *CallInfo, result interface{}, next Invoker) error {
+		return decodeResponse([]byte(`{}`), result)
+	})
+
+	if err := rpc.UpdatePlayerPlaylist(context.Background(), 10, 1); err != nil {
+		t.Fatalf("UpdatePlayerPlaylist() error = %v", err)
+	}
+	playlists, err := rpc.GetActivePlaylists(context.Background())
+	if err != nil {
+		t.Fatalf("GetActivePlaylists() error = %v", err)
+	}
+	if len(playlists.CasualPlaylists) != 0 {
+		t.Errorf("playlists = %+v, want empty", playlists)
+	}
+	if summary := decoder.Summary(); len(summary) != 0 {
+		t.Errorf("summary = %+v, want no issues", summary)
+	}
+}
@@ -0,0 +1,200 @@
+package rlapi
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+const defaultSubscriptionBuffer = 32
+
+// ErrSlowSubscriber is reported by a subscription that was disconnected by OverflowDisconnect.
+var ErrSlowSubscriber = errors.New("subscriber disconnected: event buffer full")
+
+// OverflowPolicy decides what happens when a subscriber's buffer is full.
+type OverflowPolicy int
+
+const (
+	// OverflowDropNewest discards the incoming event.
+	OverflowDropNewest OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest buffered event to make room.
+	OverflowDropOldest
+	// OverflowBlock waits for the subscriber, stalling delivery to every other subscriber and response routing.
+	OverflowBlock
+	// OverflowDisconnect closes the subscription, Err then returns ErrSlowSubscriber.
+	OverflowDisconnect
+)
+
+// SubscribeOptions configures a subscription, zero values fall back to defaults.
+type SubscribeOptions struct {
+	// Buffer is the number of events buffered for the subscriber. Defaults to 32.
+	Buffer int
+	// Overflow is applied when the buffer is full. Defaults to OverflowDropNewest.
+	Overflow OverflowPolicy
+	// Services only delivers messages pushed by these services, with or without version.
+	// Connection events are always delivered, empty delivers every message.
+	Services []string
+}
+
+// Subscription is an independent stream of events from a PsyNetRPC connection.
+type Subscription struct {
+	rpc      *PsyNetRPC
+	ch       chan *Event
+	done     chan struct{}
+	overflow OverflowPolicy
+	services map[string]bool
+	dropped  atomic.Uint64
+
+	mu     sync.Mutex
+	once   sync.Once
+	closed bool
+	err    error
+}
+
+// Subscribe creates a new event stream, every subscriber receives its own copy of each event.
+func (p *PsyNetRPC) Subscribe(opts SubscribeOptions) *Subscription {
+	buffer := opts.Buffer
+	if buffer <= 0 {
+		buffer = defaultSubscriptionBuffer
+	}
+
+	sub := &Subscription{
+		rpc:      p,
+		ch:       make(chan *Event, buffer),
+		done:     make(chan struct{}),
+		overflow: opts.Overflow,
+	}
+	if len(opts.Services) > 0 {
+		sub.services = make(map[string]bool, len(opts.Services))
+		for _, service := range opts.Services {
+			sub.services[serviceBaseName(service)] = true
+		}
+	}
+
+	p.subsMu.Lock()
+	defer p.subsMu.Unlock()
+	p.subs = append(p.subs, sub)
+
+	return sub
+}
+
+// Events returns the subscriber's channel, it is closed once the subscription ends.
+func (s *Subscription) Events() <-chan *Event {
+	return s.ch
+}
+
+// Dropped returns the number of events discarded because the buffer was full.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Err returns ErrSlowSubscriber if the subscription was disconnected for falling behind, nil otherwise.
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close unsubscribes and closes the events channel.
+func (s *Subscription) Close() {
+	s.rpc.unsubscribe(s)
+	s.once.Do(func() { close(s.done) })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeLocked()
+}
+
+func (s *Subscription) closeLocked() {
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
+
+func (s *Subscription) matches(event *Event) bool {
+	if s.services == nil || event.Type != EventTypeMessage {
+		return true
+	}
+	return s.services[serviceBaseName(event.Service)]
+}
+
+// deliver hands the event to the subscriber, reporting false if the subscriber must be removed.
+func (s *Subscription) deliver(event *Event, logger *slog.Logger) bool {
+	if !s.matches(event) {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	switch s.overflow {
+	case OverflowBlock:
+		select {
+		case s.ch <- event:
+		case <-s.done:
+		}
+		return true
+	case OverflowDropOldest:
+		for {
+			select {
+			case s.ch <- event:
+				return true
+			default:
+			}
+			select {
+			case <-s.ch:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	}
+
+	select {
+	case s.ch <- event:
+		return true
+	default:
+	}
+
+	s.dropped.Add(1)
+	logger.Warn("event channel is full, dropping event",
+		slog.Int("type", int(event.Type)),
+		slog.String("service", event.Service),
+		slog.String("content", event.Content))
+
+	if s.overflow == OverflowDisconnect {
+		s.err = ErrSlowSubscriber
+		s.closeLocked()
+		return false
+	}
+	return true
+}
+
+func (p *PsyNetRPC) unsubscribe(sub *Subscription) {
+	p.subsMu.Lock()
+	defer p.subsMu.Unlock()
+	for i, s := range p.subs {
+		if s == sub {
+			p.subs = append(p.subs[:i:i], p.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// publish fans the event out to every subscriber.
+func (p *PsyNetRPC) publish(event *Event) {
+	p.subsMu.RLock()
+	subs := p.subs
+	p.subsMu.RUnlock()
+
+	for _, sub := range subs {
+		if !sub.deliver(event, p.logger) {
+			p.unsubscribe(sub)
+		}
+	}
+}
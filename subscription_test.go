@@ -0,0 +1,78 @@
+package rlapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPsyNetRPC_Subscribe(t *testing.T) {
+	rpc := newPsyNetRPC(nil, "test-player", NewPsyNet())
+
+	party := rpc.Subscribe(SubscribeOptions{Services: []string{"Party/System"}})
+	all := rpc.Subscribe(SubscribeOptions{})
+	defer party.Close()
+	defer all.Close()
+
+	rpc.publish(&Event{Type: EventTypeMessage, Service: "Party/System v1", Content: "system"})
+	rpc.publish(&Event{Type: EventTypeMessage, Service: "Clubs/System v1", Content: "club"})
+	rpc.sendEvent(EventTypeDisconnected, "")
+
+	if got := drain(party); len(got) != 2 || got[0].Content != "system" || got[1].Type != EventTypeDisconnected {
+		t.Errorf("party subscriber got %v", got)
+	}
+	if got := drain(all); len(got) != 3 {
+		t.Errorf("all subscriber got %d events, want 3", len(got))
+	}
+	if got := drain(rpc.events); len(got) != 3 {
+		t.Errorf("default subscriber got %d events, want 3", len(got))
+	}
+}
+
+func TestPsyNetRPC_SubscribeOverflow(t *testing.T) {
+	rpc := newPsyNetRPC(nil, "test-player", NewPsyNet())
+
+	newest := rpc.Subscribe(SubscribeOptions{Buffer: 2, Overflow: OverflowDropNewest})
+	oldest := rpc.Subscribe(SubscribeOptions{Buffer: 2, Overflow: OverflowDropOldest})
+	disconnect := rpc.Subscribe(SubscribeOptions{Buffer: 2, Overflow: OverflowDisconnect})
+
+	for _, content := range []string{"a", "b", "c"} {
+		rpc.publish(&Event{Type: EventTypeMessage, Content: content})
+	}
+
+	if got := drain(newest); newest.Dropped() != 1 || got[0].Content != "a" || got[1].Content != "b" {
+		t.Errorf("drop newest kept %v, dropped %d", got, newest.Dropped())
+	}
+	if got := drain(oldest); oldest.Dropped() != 1 || got[0].Content != "b" || got[1].Content != "c" {
+		t.Errorf("drop oldest kept %v, dropped %d", got, oldest.Dropped())
+	}
+
+	drain(disconnect)
+	if _, ok := <-disconnect.Events(); ok {
+		t.Error("expected disconnected subscriber channel to be closed")
+	}
+	if !errors.Is(disconnect.Err(), ErrSlowSubscriber) {
+		t.Errorf("Err() = %v, want %v", disconnect.Err(), ErrSlowSubscriber)
+	}
+
+	rpc.subsMu.RLock()
+	n := len(rpc.subs)
+	rpc.subsMu.RUnlock()
+	if n != 3 {
+		t.Errorf("subscribers = %d, want 3 after disconnect", n)
+	}
+}
+
+func drain(sub *Subscription) []*Event {
+	var events []*Event
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Command extra generates the JSON methods of response types that keep unknown members in an Extra field.
+//
+// Usage:
+//
+//	go run ./tools/extra -dir . -out extra_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the package to scan")
+	out := flag.String("out", "extra_gen.go", "generated file name, relative to dir")
+	flag.Parse()
+
+	types, err := findTypes(*dir, *out)
+	if err != nil {
+		log.Fatalf("Failed to scan package: %v", err)
+	}
+	src, err := generate(types)
+	if err != nil {
+		log.Fatalf("Failed to generate: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, *out), src, 0o644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+}
+
+// findTypes returns the sorted names of the package's struct types with an Extra map[string]json.RawMessage field.
+// Those must be top-level results, a type with Extra nested in another one is an error.
+// Types embedding such a type are skipped, they get its methods promoted and the embedded Extra keeps their unknown
+// members. Generating methods for them as well would collect every unknown member twice, so they may not declare
+// fields of their own, which the promoted methods would ignore.
+func findTypes(dir, out string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	structs := make(map[string]*ast.StructType)
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || filepath.Base(path) == out {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if st, ok := ts.Type.(*ast.StructType); ok {
+					structs[ts.Name.Name] = st
+				}
+			}
+		}
+	}
+
+	var names []string
+	for name, st := range structs {
+		embedded := embeddedExtra(structs, st, map[string]bool{name: true})
+		switch {
+		case embedded != "" && len(st.Fields.List) > 1:
+			return nil, fmt.Errorf("%s embeds %s which keeps an Extra field, it can't declare fields of its own", name, embedded)
+		case embedded == "" && hasExtra(st):
+			names = append(names, name)
+		}
+	}
+
+	// only results keep Extra, collecting it on nested types would scan every element of a large result twice
+	extra := make(map[string]bool, len(names))
+	for _, name := range names {
+		extra[name] = true
+	}
+	for _, name := range names {
+		for _, field := range structs[name].Fields.List {
+			if nested := baseType(field.Type); extra[nested] {
+				return nil, fmt.Errorf("%s nests %s, only the top-level result can keep an Extra field", name, nested)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// baseType returns the name of the type a field holds, looking through pointers, slices and map values.
+func baseType(expr ast.Expr) string {
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.ArrayType:
+			expr = t.Elt
+		case *ast.MapType:
+			expr = t.Value
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// embeddedExtra returns the name of the field embedding a type that carries an Extra field, directly or through its
+// own embedded fields.
+func embeddedExtra(structs map[string]*ast.StructType, st *ast.StructType, visited map[string]bool) string {
+	for _, field := range st.Fields.List {
+		if len(field.Names) > 0 {
+			continue
+		}
+		typ := field.Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+		ident, ok := typ.(*ast.Ident)
+		if !ok || visited[ident.Name] {
+			continue
+		}
+		embedded, ok := structs[ident.Name]
+		if !ok {
+			continue
+		}
+
+		visited[ident.Name] = true
+		if hasExtra(embedded) || embeddedExtra(structs, embedded, visited) != "" {
+			return ident.Name
+		}
+	}
+	return ""
+}
+
+func hasExtra(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		for _, name := range field.Names {
+			if name.Name == "Extra" && types.ExprString(field.Type) == "map[string]json.RawMessage" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var goTemplate = template.Must(template.New("go").Parse(`// Code generated by tools/extra. DO NOT EDIT.
+
+package rlapi
+
+{{range .}}
+func (r *{{.}}) UnmarshalJSON(data []byte) error {
+	type plain {{.}}
+	return unmarshalExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r {{.}}) MarshalJSON() ([]byte, error) {
+	type plain {{.}}
+	return marshalExtra(plain(r), r.Extra)
+}
+{{end}}`))
+
+func generate(types []string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := goTemplate.Execute(&buf, types); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return src, nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGeneratedUpToDate fails when extra_gen.go misses a type with an Extra field, run go generate to fix it.
+func TestGeneratedUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+
+	types, err := findTypes(root, "extra_gen.go")
+	if err != nil {
+		t.Fatalf("findTypes() error = %v", err)
+	}
+	if len(types) == 0 {
+		t.Fatal("Expected types with an Extra field")
+	}
+
+	want, err := generate(types)
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(root, "extra_gen.go"))
+	if err != nil {
+		t.Fatalf("failed to read extra_gen.go: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Error("extra_gen.go is out of date, run go generate")
+	}
+}
+
+func TestFindTypes_Embedding(t *testing.T) {
+	dir := t.TempDir()
+	src := `package rlapi
+
+import "encoding/json"
+
+type Invite struct {
+	ClubID int
+	Extra  map[string]json.RawMessage ` + "`json:\"-\"`" + `
+}
+
+type InviteMessage struct {
+	Invite
+}
+
+type InviteEvent struct {
+	*InviteMessage
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	types, err := findTypes(dir, "extra_gen.go")
+	if err != nil {
+		t.Fatalf("findTypes() error = %v", err)
+	}
+	if len(types) != 1 || types[0] != "Invite" {
+		t.Errorf("findTypes() = %v, want only Invite", types)
+	}
+
+	own := src + `
+type InviteReply struct {
+	Invite
+	Accepted bool
+	Extra    map[string]json.RawMessage ` + "`json:\"-\"`" + `
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(own), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := findTypes(dir, "extra_gen.go"); err == nil {
+		t.Error("Expected an error for an embedding type with fields of its own")
+	}
+}
+
+func TestFindTypes_Nested(t *testing.T) {
+	dir := t.TempDir()
+	src := `package rlapi
+
+import "encoding/json"
+
+type Item struct {
+	ID    int
+	Extra map[string]json.RawMessage ` + "`json:\"-\"`" + `
+}
+
+type GetItemsResponse struct {
+	Items []*Item
+	Extra map[string]json.RawMessage ` + "`json:\"-\"`" + `
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := findTypes(dir, "extra_gen.go"); err == nil {
+		t.Error("Expected an error for a type with Extra nested in a result")
+	}
+}
@@ -0,0 +1,589 @@
+// Command gen generates service wrappers and REQUESTS.md sections from the JSON specs in spec/.
+// Only some service groups have a spec yet, the others are hand-written and not checked for drift.
+//
+// Usage:
+//
+//	go run ./tools/gen -spec spec -out . -docs REQUESTS.md
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Spec describes a service group, it is generated into one Go file and one REQUESTS.md section.
+type Spec struct {
+	// Group is the service prefix, e.g. "Population".
+	Group string `json:"group"`
+	// Title is the REQUESTS.md heading, defaults to Group.
+	Title string `json:"title"`
+	// File is the generated Go file name, defaults to the lowercased group.
+	File     string        `json:"file"`
+	Types    []TypeSpec    `json:"types"`
+	Services []ServiceSpec `json:"services"`
+
+	source string
+}
+
+// TypeSpec describes a named type, either a struct with Fields or a defined type of Type.
+type TypeSpec struct {
+	Name   string      `json:"name"`
+	Doc    string      `json:"doc"`
+	Type   string      `json:"type"`
+	Fields []FieldSpec `json:"fields"`
+}
+
+type FieldSpec struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// JSON is the field's JSON name, defaults to Name.
+	JSON string `json:"json"`
+}
+
+func (f FieldSpec) wireName() string {
+	if f.JSON != "" {
+		return f.JSON
+	}
+	return f.Name
+}
+
+type ServiceSpec struct {
+	Service string `json:"service"`
+	Version int    `json:"version"`
+	Method  string `json:"method"`
+	// Doc is a sentence describing the service, used for the method comment and REQUESTS.md.
+	Doc string `json:"doc"`
+	// Request is the request type, an empty request is sent when unset.
+	Request string `json:"request"`
+	// Params are the method parameters, each filling a request field. Params with a Value set the field without a parameter.
+	Params []ParamSpec `json:"params"`
+	// Response is the type the result is decoded into, it is discarded when unset.
+	Response string `json:"response"`
+	// Returns is the response field returned by the method, "*" returns the whole response and empty returns only an error.
+	Returns string `json:"returns"`
+}
+
+type ParamSpec struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+func main() {
+	specDir := flag.String("spec", "spec", "directory containing the JSON specs")
+	outDir := flag.String("out", ".", "directory the Go files are written to")
+	docs := flag.String("docs", "REQUESTS.md", "markdown file whose sections are regenerated, empty to skip")
+	flag.Parse()
+
+	specs, err := loadSpecs(*specDir)
+	if err != nil {
+		log.Fatalf("Failed to load specs: %v", err)
+	}
+
+	for _, spec := range specs {
+		src, err := generateGo(spec)
+		if err != nil {
+			log.Fatalf("Failed to generate %s: %v", spec.source, err)
+		}
+		if err := os.WriteFile(filepath.Join(*outDir, spec.File), src, 0o644); err != nil {
+			log.Fatalf("Failed to write %s: %v", spec.File, err)
+		}
+	}
+
+	if *docs != "" {
+		data, err := os.ReadFile(*docs)
+		if err != nil {
+			log.Fatalf("Failed to read docs: %v", err)
+		}
+		updated, err := updateDocs(string(data), specs)
+		if err != nil {
+			log.Fatalf("Failed to update docs: %v", err)
+		}
+		if err := os.WriteFile(*docs, []byte(updated), 0o644); err != nil {
+			log.Fatalf("Failed to write docs: %v", err)
+		}
+	}
+}
+
+func loadSpecs(dir string) ([]*Spec, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	specs := make([]*Spec, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var spec Spec
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&spec); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		spec.source = filepath.ToSlash(path)
+		if spec.Title == "" {
+			spec.Title = spec.Group
+		}
+		if spec.File == "" {
+			spec.File = strings.ToLower(spec.Group) + ".go"
+		}
+		if err := spec.validate(); err != nil {
+			return nil, fmt.Errorf("invalid spec %s: %w", path, err)
+		}
+		specs = append(specs, &spec)
+	}
+
+	return specs, nil
+}
+
+func (s *Spec) validate() error {
+	for _, svc := range s.Services {
+		if svc.Service == "" || svc.Method == "" || svc.Version <= 0 {
+			return fmt.Errorf("service %q needs a service, method and version", svc.Method)
+		}
+		if svc.Returns != "" {
+			if svc.Response == "" {
+				return fmt.Errorf("%s returns %s without a response type", svc.Method, svc.Returns)
+			}
+			if _, err := s.returnType(svc); err != nil {
+				return err
+			}
+		}
+		if len(svc.Params) > 0 && svc.Request == "" {
+			return fmt.Errorf("%s has params without a request type", svc.Method)
+		}
+	}
+	return nil
+}
+
+// field returns the field with the given JSON name.
+func (t *TypeSpec) field(wireName string) *FieldSpec {
+	for i := range t.Fields {
+		if t.Fields[i].wireName() == wireName {
+			return &t.Fields[i]
+		}
+	}
+	return nil
+}
+
+// results returns the struct types services decode their result into, those keep unknown members in an Extra field.
+// Types nested in a result don't, collecting their members would scan every element twice, see StrictDecoder.
+func (s *Spec) results() map[string]bool {
+	results := make(map[string]bool)
+	for _, svc := range s.Services {
+		if t := s.lookup(svc.Response); t != nil && len(t.Fields) > 0 {
+			results[t.Name] = true
+		}
+	}
+	return results
+}
+
+func (s *Spec) lookup(name string) *TypeSpec {
+	for i := range s.Types {
+		if s.Types[i].Name == name {
+			return &s.Types[i]
+		}
+	}
+	return nil
+}
+
+// returnType resolves the Go type returned by a service method.
+func (s *Spec) returnType(svc ServiceSpec) (string, error) {
+	switch svc.Returns {
+	case "":
+		return "", nil
+	case "*":
+		return "*" + svc.Response, nil
+	}
+
+	resp := s.lookup(svc.Response)
+	if resp == nil {
+		return "", fmt.Errorf("%s: response type %s is not defined in the spec", svc.Method, svc.Response)
+	}
+	for _, field := range resp.Fields {
+		if field.Name == svc.Returns {
+			return field.Type, nil
+		}
+	}
+	return "", fmt.Errorf("%s: response type %s has no field %s", svc.Method, svc.Response, svc.Returns)
+}
+
+// zeroValue returns the literal for the zero value of a type.
+func (s *Spec) zeroValue(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "*"), strings.HasPrefix(typ, "map["),
+		typ == "interface{}", typ == "json.RawMessage":
+		return "nil"
+	case typ == "string":
+		return `""`
+	case typ == "bool":
+		return "false"
+	case strings.HasPrefix(typ, "int"), strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "float"):
+		return "0"
+	}
+
+	if t := s.lookup(typ); t != nil && t.Type != "" {
+		return s.zeroValue(t.Type)
+	}
+	return typ + "{}"
+}
+
+var funcs = template.FuncMap{
+	"jsonName": FieldSpec.wireName,
+	"methodDoc": func(method, doc string) string {
+		if doc == "" {
+			return ""
+		}
+		return method + " " + strings.ToLower(doc[:1]) + doc[1:]
+	},
+}
+
+var goTemplate = template.Must(template.New("go").Funcs(funcs).Parse(`// Code generated by tools/gen from {{.Source}}. DO NOT EDIT.
+
+package rlapi
+
+{{if eq (len .Imports) 1}}import "{{index .Imports 0}}"{{else if .Imports}}import (
+{{range .Imports}}	"{{.}}"
+{{end}}){{end}}
+
+{{range .Spec.Types}}
+{{if .Doc}}// {{.Doc}}
+{{end}}{{if .Fields}}type {{.Name}} struct {
+{{range .Fields}}	{{.Name}} {{.Type}} ` + "`" + `json:"{{jsonName .}}"` + "`" + `
+{{end}}{{if index $.Results .Name}}
+	Extra map[string]json.RawMessage ` + "`" + `json:"-"` + "`" + `
+{{end}}}{{else}}type {{.Name}} {{.Type}}{{end}}
+{{end}}
+
+{{range .Methods}}
+{{with methodDoc .Service.Method .Service.Doc}}// {{.}}
+{{end}}func (p *PsyNetRPC) {{.Service.Method}}(ctx context.Context{{range .Params}}, {{.Name}} {{.Type}}{{end}}) {{if .ReturnType}}({{.ReturnType}}, error){{else}}error{{end}} {
+{{if .Service.Request}}	request := {{.Service.Request}}{
+{{range .Service.Params}}		{{.Field}}: {{if .Value}}{{.Value}}{{else}}{{.Name}}{{end}},
+{{end}}	}
+
+{{end}}	var result {{if .Service.Response}}{{.Service.Response}}{{else}}interface{}{{end}}
+	err := p.sendRequestSync(ctx, "{{.Service.Service}} v{{.Service.Version}}", {{if .Service.Request}}request{{else}}emptyRequest{}{{end}}, &result)
+	if err != nil {
+		return {{if .ReturnType}}{{.Zero}}, {{end}}err
+	}
+	return {{if eq .Service.Returns "*"}}&result, {{else if .Service.Returns}}result.{{.Service.Returns}}, {{end}}nil
+}
+{{end}}`))
+
+type method struct {
+	Service    ServiceSpec
+	Params     []ParamSpec
+	ReturnType string
+	Zero       string
+}
+
+func generateGo(spec *Spec) ([]byte, error) {
+	methods := make([]method, 0, len(spec.Services))
+	for _, svc := range spec.Services {
+		returnType, err := spec.returnType(svc)
+		if err != nil {
+			return nil, err
+		}
+
+		m := method{Service: svc, ReturnType: returnType}
+		if returnType != "" {
+			m.Zero = spec.zeroValue(returnType)
+		}
+		for _, param := range svc.Params {
+			if param.Value == "" {
+				m.Params = append(m.Params, param)
+			}
+		}
+		methods = append(methods, m)
+	}
+
+	var imports []string
+	if len(spec.Services) > 0 {
+		imports = append(imports, "context")
+	}
+	results := spec.results()
+	if len(results) > 0 {
+		imports = append(imports, "encoding/json")
+	}
+
+	var buf bytes.Buffer
+	if err := goTemplate.Execute(&buf, map[string]interface{}{
+		"Spec":    spec,
+		"Methods": methods,
+		"Imports": imports,
+		"Results": results,
+		"Source":  spec.source,
+	}); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w\n%s", err, buf.String())
+	}
+	return src, nil
+}
+
+// updateDocs regenerates the section of every spec in the markdown, from its "### Title" heading to the next one.
+// Captured examples already in a section are kept verbatim and checked against the spec, services without one get
+// an example built from the spec's wire names. Sections missing from the markdown are appended.
+func updateDocs(doc string, specs []*Spec) (string, error) {
+	for _, spec := range specs {
+		heading := "### " + spec.Title + "\n"
+		start := strings.Index(doc, heading)
+		end := len(doc)
+		if start != -1 {
+			if next := strings.Index(doc[start+len(heading):], "\n### "); next != -1 {
+				end = start + len(heading) + next + 1
+			}
+		}
+
+		var existing string
+		if start != -1 {
+			existing = doc[start:end]
+		}
+		section, err := generateDocs(spec, existing)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate docs for %s: %w", spec.source, err)
+		}
+
+		if start == -1 {
+			doc = strings.TrimRight(doc, "\n") + "\n\n" + section
+			continue
+		}
+		doc = doc[:start] + section + doc[end:]
+	}
+	return doc, nil
+}
+
+// generateDocs writes the section of a spec, existing is the current section and may be empty.
+func generateDocs(spec *Spec, existing string) (string, error) {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "### %s\n", spec.Title)
+
+	services := append([]ServiceSpec(nil), spec.Services...)
+	sort.Slice(services, func(i, j int) bool { return services[i].Service < services[j].Service })
+
+	for _, svc := range services {
+		heading := fmt.Sprintf("#### %s v%d\n", svc.Service, svc.Version)
+		buf.WriteString(heading)
+		if svc.Doc != "" {
+			fmt.Fprintf(&buf, "%s\n", svc.Doc)
+		}
+
+		examples := capturedExamples(existing, heading)
+		if examples == "" {
+			generated, err := spec.exampleDocs(svc)
+			if err != nil {
+				return "", err
+			}
+			examples = generated
+		} else if err := spec.checkExamples(svc, examples); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&buf, "\n%s", examples)
+	}
+
+	return buf.String(), nil
+}
+
+// capturedExamples returns the example blocks under a service heading, from the first "######" heading to the next service.
+func capturedExamples(section, heading string) string {
+	start := strings.Index(section, heading)
+	if start == -1 {
+		return ""
+	}
+	sub := section[start+len(heading):]
+	if next := strings.Index(sub, "\n#### "); next != -1 {
+		sub = sub[:next+1]
+	}
+
+	examples := strings.Index(sub, "###### ")
+	if examples == -1 {
+		return ""
+	}
+	return sub[examples:]
+}
+
+// exampleDocs builds the example blocks of a service from the wire names of its request and response types.
+func (s *Spec) exampleDocs(svc ServiceSpec) (string, error) {
+	request := "{}"
+	if svc.Request != "" {
+		request = s.exampleValue(svc.Request)
+	}
+	response := "{}"
+	if svc.Response != "" {
+		response = s.exampleValue(svc.Response)
+	}
+
+	var req, resp bytes.Buffer
+	if err := json.Indent(&req, []byte(request), "", "  "); err != nil {
+		return "", fmt.Errorf("%s request example: %w", svc.Method, err)
+	}
+	if err := json.Indent(&resp, []byte(`{"Result":`+response+`}`), "", "  "); err != nil {
+		return "", fmt.Errorf("%s response example: %w", svc.Method, err)
+	}
+	return fmt.Sprintf("###### Request\n```json5\n%s\n```\n\n###### Response\n```json5\n%s\n```\n\n", req.String(), resp.String()), nil
+}
+
+// exampleValue returns a placeholder JSON value of a type, types defined outside the spec are null.
+func (s *Spec) exampleValue(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "[]"):
+		return "[" + s.exampleValue(typ[2:]) + "]"
+	case strings.HasPrefix(typ, "*"):
+		return s.exampleValue(typ[1:])
+	case typ == "string":
+		return `""`
+	case typ == "bool":
+		return "false"
+	case strings.HasPrefix(typ, "int"), strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "float"):
+		return "0"
+	}
+
+	t := s.lookup(typ)
+	switch {
+	case t == nil:
+		return "null"
+	case t.Type != "":
+		return s.exampleValue(t.Type)
+	}
+
+	fields := make([]string, 0, len(t.Fields))
+	for _, field := range t.Fields {
+		name, _ := json.Marshal(field.wireName())
+		fields = append(fields, string(name)+":"+s.exampleValue(field.Type))
+	}
+	return "{" + strings.Join(fields, ",") + "}"
+}
+
+// checkExamples fails when a captured example uses a member the spec doesn't declare, so renamed wire names are caught.
+func (s *Spec) checkExamples(svc ServiceSpec, examples string) error {
+	if svc.Request != "" {
+		if request, ok := codeBlock(examples, "###### Request"); ok {
+			value, err := parseJSON5(request)
+			if err != nil {
+				return fmt.Errorf("%s request example: %w", svc.Method, err)
+			}
+			if err := s.checkValue(svc.Request, value, "request"); err != nil {
+				return fmt.Errorf("%s request example: %w", svc.Method, err)
+			}
+		}
+	}
+
+	if svc.Response != "" {
+		if response, ok := codeBlock(examples, "###### Response"); ok {
+			value, err := parseJSON5(response)
+			if err != nil {
+				return fmt.Errorf("%s response example: %w", svc.Method, err)
+			}
+			if wrapper, ok := value.(map[string]interface{}); ok {
+				if err := s.checkValue(svc.Response, wrapper["Result"], "Result"); err != nil {
+					return fmt.Errorf("%s response example: %w", svc.Method, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Spec) checkValue(typ string, value interface{}, path string) error {
+	switch {
+	case value == nil:
+		return nil
+	case strings.HasPrefix(typ, "[]"):
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s is not an array", path)
+		}
+		for i, item := range items {
+			if err := s.checkValue(typ[2:], item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case strings.HasPrefix(typ, "*"):
+		return s.checkValue(typ[1:], value, path)
+	}
+
+	t := s.lookup(typ)
+	switch {
+	case t == nil:
+		return nil
+	case t.Type != "":
+		return s.checkValue(t.Type, value, path)
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s is not an object", path)
+	}
+	for key, member := range object {
+		field := t.field(key)
+		if field == nil {
+			return fmt.Errorf("%s has member %q that %s doesn't declare", path, key, t.Name)
+		}
+		if err := s.checkValue(field.Type, member, path+"."+key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// codeBlock returns the contents of the first code block after a heading.
+func codeBlock(text, heading string) (string, bool) {
+	start := strings.Index(text, heading+"\n")
+	if start == -1 {
+		return "", false
+	}
+	text = text[start:]
+
+	open := strings.Index(text, "```")
+	if open == -1 {
+		return "", false
+	}
+	text = text[open+3:]
+	if newline := strings.Index(text, "\n"); newline != -1 {
+		text = text[newline+1:]
+	}
+
+	end := strings.Index(text, "```")
+	if end == -1 {
+		return "", false
+	}
+	return text[:end], true
+}
+
+// parseJSON5 decodes a captured example, dropping the "// ..." comment lines that mark elided members.
+func parseJSON5(text string) (interface{}, error) {
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "//") {
+			kept = append(kept, line)
+		}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(strings.Join(kept, "\n")), &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
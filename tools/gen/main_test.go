@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedUpToDate fails when the checked-in files differ from the specs, run go generate to fix it.
+func TestGeneratedUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+
+	specs, err := loadSpecs(filepath.Join(root, "spec"))
+	if err != nil {
+		t.Fatalf("loadSpecs() error = %v", err)
+	}
+	for _, spec := range specs {
+		spec.source = strings.TrimPrefix(spec.source, "../../")
+	}
+
+	for _, spec := range specs {
+		want, err := generateGo(spec)
+		if err != nil {
+			t.Fatalf("generateGo(%s) error = %v", spec.source, err)
+		}
+		got, err := os.ReadFile(filepath.Join(root, spec.File))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", spec.File, err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("%s is out of date with %s, run go generate", spec.File, spec.source)
+		}
+	}
+
+	docs, err := os.ReadFile(filepath.Join(root, "REQUESTS.md"))
+	if err != nil {
+		t.Fatalf("failed to read REQUESTS.md: %v", err)
+	}
+	updated, err := updateDocs(string(docs), specs)
+	if err != nil {
+		t.Fatalf("updateDocs() error = %v", err)
+	}
+	if updated != string(docs) {
+		t.Error("REQUESTS.md is out of date with the specs, run go generate")
+	}
+}
+
+func TestSpecValidation(t *testing.T) {
+	spec := &Spec{
+		Types: []TypeSpec{{Name: "Response", Fields: []FieldSpec{{Name: "Items", Type: "[]int"}}}},
+		Services: []ServiceSpec{{
+			Service:  "Test/GetItems",
+			Version:  1,
+			Method:   "GetItems",
+			Response: "Response",
+			Returns:  "Missing",
+		}},
+	}
+	if err := spec.validate(); err == nil {
+		t.Error("Expected an error for a missing return field")
+	}
+
+	spec.Services[0].Returns = "Items"
+	if err := spec.validate(); err != nil {
+		t.Errorf("validate() error = %v", err)
+	}
+}
+
+func testSpec() *Spec {
+	return &Spec{
+		Group: "Test",
+		Title: "Test",
+		Types: []TypeSpec{
+			{Name: "Item", Fields: []FieldSpec{{Name: "ItemID", Type: "int", JSON: "Item"}, {Name: "Name", Type: "string"}}},
+			{Name: "GetItemsResponse", Fields: []FieldSpec{{Name: "Items", Type: "[]Item"}}},
+		},
+		Services: []ServiceSpec{{
+			Service:  "Test/GetItems",
+			Version:  1,
+			Method:   "GetItems",
+			Doc:      "Retrieves the items.",
+			Response: "GetItemsResponse",
+			Returns:  "Items",
+		}},
+	}
+}
+
+func TestUpdateDocs_KeepsCapturedExamples(t *testing.T) {
+	doc := "### Test\n#### Test/GetItems v1\nOld prose.\n\n###### Request\n```json5\n{}\n```\n\n" +
+		"###### Response\n```json5\n{\n  \"Result\": {\n    \"Items\": [\n      {\n        \"Item\": 1,\n        \"Name\": \"a\"\n      }\n" +
+		"      // ... additional items\n    ]\n  }\n}\n```\n\n### Other\n"
+
+	updated, err := updateDocs(doc, []*Spec{testSpec()})
+	if err != nil {
+		t.Fatalf("updateDocs() error = %v", err)
+	}
+	want := strings.Replace(doc, "Old prose.", "Retrieves the items.", 1)
+	if updated != want {
+		t.Errorf("updateDocs() =\n%s\nwant\n%s", updated, want)
+	}
+
+	drifted := strings.Replace(doc, `"Item": 1`, `"ItemID": 1`, 1)
+	if _, err := updateDocs(drifted, []*Spec{testSpec()}); err == nil || !strings.Contains(err.Error(), `"ItemID"`) {
+		t.Errorf("updateDocs() error = %v, want the undeclared member reported", err)
+	}
+}
+
+func TestUpdateDocs_GeneratesExamplesFromWireNames(t *testing.T) {
+	updated, err := updateDocs("# Requests\n", []*Spec{testSpec()})
+	if err != nil {
+		t.Fatalf("updateDocs() error = %v", err)
+	}
+	want := "# Requests\n\n### Test\n#### Test/GetItems v1\nRetrieves the items.\n\n###### Request\n```json5\n{}\n```\n\n" +
+		"###### Response\n```json5\n{\n  \"Result\": {\n    \"Items\": [\n      {\n        \"Item\": 0,\n        \"Name\": \"\"\n      }\n    ]\n  }\n}\n```\n\n"
+	if updated != want {
+		t.Errorf("updateDocs() =\n%s\nwant\n%s", updated, want)
+	}
+}
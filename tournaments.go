@@ -1,6 +1,9 @@
 package rlapi
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 type TournamentID string
 
@@ -60,10 +63,10 @@ type TournamentRequirements struct {
 
 // TournamentReward represents a reward for tournament participation
 type TournamentReward struct {
-	Rank         int           `json:"Rank"`
-	Products     []Product     `json:"Products"`
-	Currency     []interface{} `json:"Currency"`
-	TournamentXP int           `json:"TournamentXP"`
+	Rank         int               `json:"Rank"`
+	Products     []Product         `json:"Products"`
+	Currency     []json.RawMessage `json:"Currency"`
+	TournamentXP int               `json:"TournamentXP"`
 }
 
 // TournamentSubscription represents a player's tournament subscription
@@ -85,6 +88,8 @@ type GetScheduleRegionRequest struct {
 
 type GetScheduleRegionResponse struct {
 	ScheduleRegion string `json:"ScheduleRegion"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetCycleDataRequest struct {
@@ -92,13 +97,15 @@ type GetCycleDataRequest struct {
 }
 
 type GetCycleDataResponse struct {
-	CycleID              int           `json:"CycleID"`
-	CycleEndTime         int64         `json:"CycleEndTime"`
-	WeekID               int           `json:"WeekID"`
-	WeekEndTime          int64         `json:"WeekEndTime"`
-	WeeklyCurrencies     []interface{} `json:"WeeklyCurrencies"`
-	Weeks                []interface{} `json:"Weeks"`
-	TournamentCurrencyID int           `json:"TournamentCurrencyID"`
+	CycleID              int               `json:"CycleID"`
+	CycleEndTime         int64             `json:"CycleEndTime"`
+	WeekID               int               `json:"WeekID"`
+	WeekEndTime          int64             `json:"WeekEndTime"`
+	WeeklyCurrencies     []json.RawMessage `json:"WeeklyCurrencies"`
+	Weeks                []json.RawMessage `json:"Weeks"`
+	TournamentCurrencyID int               `json:"TournamentCurrencyID"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetScheduleRequest struct {
@@ -108,6 +115,8 @@ type GetScheduleRequest struct {
 
 type GetScheduleResponse struct {
 	Schedules []TournamentSchedule `json:"Schedules"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetPublicTournamentsRequest struct {
@@ -133,6 +142,8 @@ type TournamentSearchInfo struct {
 
 type GetPublicTournamentsResponse struct {
 	Tournaments []Tournament `json:"Tournaments"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type RegisterTournamentRequest struct {
@@ -143,6 +154,8 @@ type RegisterTournamentRequest struct {
 
 type RegisterTournamentResponse struct {
 	Tournament Tournament `json:"Tournament"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type UnsubscribeTournamentRequest struct {
@@ -249,12 +262,12 @@ func (p *PsyNetRPC) UnsubscribeTournament(ctx context.Context, tournamentID Tour
 }
 
 // GetTournamentSubscriptions retrieves the authenticated player's tournament subscriptions.
-func (p *PsyNetRPC) GetTournamentSubscriptions(ctx context.Context) (interface{}, error) {
+func (p *PsyNetRPC) GetTournamentSubscriptions(ctx context.Context) (json.RawMessage, error) {
 	request := GetTournamentSubscriptionsRequest{
 		PlayerID: p.localPlayerID,
 	}
 
-	var result interface{}
+	var result json.RawMessage
 	err := p.sendRequestSync(ctx, "Tournaments/Status/GetTournamentSubscriptions v1", request, &result)
 	if err != nil {
 		return nil, err
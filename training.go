@@ -1,6 +1,9 @@
 package rlapi
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 type TrainingPack struct {
 	Code            string   `json:"Code"`
@@ -23,6 +26,8 @@ type BrowseTrainingDataRequest struct {
 
 type BrowseTrainingDataResponse struct {
 	TrainingData []TrainingPack `json:"TrainingData"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type GetTrainingMetadataRequest struct {
@@ -31,6 +36,8 @@ type GetTrainingMetadataRequest struct {
 
 type GetTrainingMetadataResponse struct {
 	TrainingData []TrainingPack `json:"TrainingData"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // BrowseTrainingData retrieves training packs.
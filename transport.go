@@ -0,0 +1,79 @@
+package rlapi
+
+import (
+	"context"
+	"strings"
+)
+
+// TransportMode selects how PsyNetRPC carries service calls.
+type TransportMode int
+
+const (
+	// TransportAuto follows UseWebSocket from the AuthPlayer response.
+	TransportAuto TransportMode = iota
+	// TransportWebSocket sends calls over the persistent WebSocket connection.
+	TransportWebSocket
+	// TransportHTTP sends every call as a signed POST to /rpc/<Service>/<vN>, for environments that block long-lived sockets.
+	TransportHTTP
+	// TransportReplay answers calls from a Cassette, see PsyNet.NewReplayRPC. It is only reported by PsyNetRPC.Transport.
+	TransportReplay
+)
+
+// rpcTransport carries service calls for PsyNetRPC in place of its WebSocket.
+type rpcTransport interface {
+	call(ctx context.Context, info *CallInfo, result interface{}) error
+}
+
+// SetTransportMode forces the transport used by connections returned from AuthPlayer, defaults to TransportAuto.
+func (p *PsyNet) SetTransportMode(mode TransportMode) {
+	p.transportMode = mode
+}
+
+// Transport returns the transport used for service calls.
+func (p *PsyNetRPC) Transport() TransportMode {
+	switch p.transport.(type) {
+	case *httpTransport:
+		return TransportHTTP
+	case *Cassette:
+		return TransportReplay
+	}
+	return TransportWebSocket
+}
+
+type httpTransport struct {
+	psyNet    *PsyNet
+	psyToken  string
+	sessionID string
+}
+
+func (p *PsyNet) newHTTPRPC(playerID PlayerID, psyToken string, sessionID string) *PsyNetRPC {
+	rpc := newPsyNetRPC(nil, playerID, p)
+	rpc.psyToken = psyToken
+	rpc.sessionID = sessionID
+	rpc.transport = &httpTransport{
+		psyNet:    p,
+		psyToken:  psyToken,
+		sessionID: sessionID,
+	}
+	return rpc
+}
+
+func (t *httpTransport) call(ctx context.Context, info *CallInfo, result interface{}) error {
+	headers := make(map[string]string, len(info.Headers)+2)
+	for key, value := range info.Headers {
+		headers[key] = value
+	}
+	headers["PsyToken"] = t.psyToken
+	headers["PsySessionID"] = t.sessionID
+	return t.psyNet.post(ctx, servicePath(info.Service), info.RequestID, headers, info.Request, result)
+}
+
+// servicePath converts a service name to its HTTP path segments, "Skills/GetPlayerSkill v1" becomes [Skills GetPlayerSkill v1].
+func servicePath(service string) []string {
+	name, version, _ := strings.Cut(service, " ")
+	path := strings.Split(name, "/")
+	if version != "" {
+		path = append(path, version)
+	}
+	return path
+}
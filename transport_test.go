@@ -0,0 +1,127 @@
+package rlapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestServicePath(t *testing.T) {
+	tests := []struct {
+		service string
+		want    []string
+	}{
+		{"Skills/GetPlayerSkill v1", []string{"Skills", "GetPlayerSkill", "v1"}},
+		{"Tournaments/Search/GetSchedule v1", []string{"Tournaments", "Search", "GetSchedule", "v1"}},
+		{"Party/System", []string{"Party", "System"}},
+	}
+	for _, tt := range tests {
+		got := servicePath(tt.service)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("servicePath(%q) = %v, want %v", tt.service, got, tt.want)
+		}
+	}
+}
+
+func TestPsyNetRPC_HTTPTransportClose(t *testing.T) {
+	rpc := NewPsyNet().newHTTPRPC("test-player", "test-token", "test-session")
+
+	if rpc.Transport() != TransportHTTP {
+		t.Errorf("Transport() = %d, want %d", rpc.Transport(), TransportHTTP)
+	}
+	if !rpc.IsConnected() {
+		t.Error("Expected http transport to be connected initially")
+	}
+
+	rpc.Close()
+
+	if rpc.IsConnected() {
+		t.Error("Expected http transport to be inactive after close")
+	}
+}
+
+func TestPsyNetRPC_HTTPTransportCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PsyToken") != "test-token" || r.Header.Get("PsySessionID") != "test-session" || r.Header.Get("PsySig") == "" {
+			t.Errorf("headers = %v, want PsyToken, PsySessionID and PsySig", r.Header)
+		}
+
+		switch r.URL.Path {
+		case "/Skills/GetPlayerSkill/v1":
+			var request GetPlayerSkillRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.PlayerID != "Epic|1|0" {
+				t.Errorf("request = %+v, %v", request, err)
+			}
+			w.Write([]byte(`{"Result":{"Skills":[{"Playlist":10,"MMR":1200}]}}`))
+		case "/Population/UpdatePlayerPlaylist/v1":
+			w.Write([]byte(`{}`))
+		default:
+			w.Write([]byte(`{"Error":{"Type":"ServiceNotFound","Message":"no such service"}}`))
+		}
+	}))
+	defer server.Close()
+
+	rpc := NewPsyNet(WithBaseURL(server.URL)).newHTTPRPC("test-player", "test-token", "test-session")
+	defer rpc.Close()
+
+	skill, err := rpc.GetPlayerSkill(context.Background(), "Epic|1|0")
+	if err != nil {
+		t.Fatalf("GetPlayerSkill() error = %v", err)
+	}
+	if len(skill.Skills) != 1 || skill.Skills[0].MMR != 1200 {
+		t.Errorf("skill = %+v", skill)
+	}
+
+	// services returning nothing may leave out the Result
+	if err := rpc.UpdatePlayerPlaylist(context.Background(), 10, 1); err != nil {
+		t.Errorf("UpdatePlayerPlaylist() error = %v", err)
+	}
+
+	var psyErr *PsyNetError
+	if _, err := rpc.GetXP(context.Background()); !errors.As(err, &psyErr) || psyErr.Type != "ServiceNotFound" {
+		t.Errorf("GetXP() error = %v, want ServiceNotFound", err)
+	}
+}
+
+func TestPsyNet_TransportAuto(t *testing.T) {
+	wsServer := NewMockWSServer()
+	defer wsServer.Close()
+
+	tests := []struct {
+		name         string
+		mode         TransportMode
+		useWebSocket bool
+		want         TransportMode
+	}{
+		{"auto follows UseWebSocket", TransportAuto, true, TransportWebSocket},
+		{"auto falls back to http", TransportAuto, false, TransportHTTP},
+		{"forced http", TransportHTTP, true, TransportHTTP},
+		{"forced websocket", TransportWebSocket, false, TransportWebSocket},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"Result":{"UseWebSocket":%t,"PerConURLv2":%q,"PsyToken":"token","SessionID":"session"}}`,
+					tt.useWebSocket, wsServer.URL())
+			}))
+			defer server.Close()
+
+			psyNet := NewPsyNet(WithBaseURL(server.URL))
+			psyNet.SetTransportMode(tt.mode)
+			rpc, err := psyNet.AuthPlayer("token", "account", "name")
+			if err != nil {
+				t.Fatalf("AuthPlayer() error = %v", err)
+			}
+			defer rpc.Close()
+
+			if rpc.Transport() != tt.want {
+				t.Errorf("Transport() = %d, want %d", rpc.Transport(), tt.want)
+			}
+		})
+	}
+}
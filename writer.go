@@ -0,0 +1,153 @@
+package rlapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultWriteTimeout = 10 * time.Second
+	defaultWriteQueue   = 256
+)
+
+// errWriterStopped is returned for messages that were queued when the connection went away.
+var errWriterStopped = errors.New("writer stopped")
+
+// outboundMessage is a frame waiting for the write pump, done receives the result of the write.
+type outboundMessage struct {
+	ctx  context.Context
+	data []byte
+	done chan error
+}
+
+// writePump is the only writer of a socket. Pings go through a priority lane so they are never
+// stuck behind bulk requests, every write has a deadline so a stalled socket fails instead of hanging.
+type writePump struct {
+	conn    *websocket.Conn
+	timeout time.Duration
+	onWrite func(n int)
+
+	priority chan *outboundMessage
+	queue    chan *outboundMessage
+	stop     chan struct{}
+	stopOnce sync.Once
+	stopped  chan struct{}
+}
+
+func newWritePump(conn *websocket.Conn, timeout time.Duration, size int, onWrite func(n int)) *writePump {
+	if timeout <= 0 {
+		timeout = defaultWriteTimeout
+	}
+	if size <= 0 {
+		size = defaultWriteQueue
+	}
+	w := &writePump{
+		conn:     conn,
+		timeout:  timeout,
+		onWrite:  onWrite,
+		priority: make(chan *outboundMessage, 1),
+		queue:    make(chan *outboundMessage, size),
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *writePump) run() {
+	defer close(w.stopped)
+
+	for {
+		// pings first, whatever is queued
+		select {
+		case msg := <-w.priority:
+			if !w.write(msg) {
+				return
+			}
+			continue
+		default:
+		}
+
+		select {
+		case msg := <-w.priority:
+			if !w.write(msg) {
+				return
+			}
+		case msg := <-w.queue:
+			if !w.write(msg) {
+				return
+			}
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// write sends one frame, a failed write leaves the socket unusable so it is closed and the pump stops.
+// The reader then sees the closed socket and handles the lost connection.
+func (w *writePump) write(msg *outboundMessage) bool {
+	if msg.ctx.Err() != nil {
+		// the caller gave up while the message was queued
+		msg.done <- msg.ctx.Err()
+		return true
+	}
+
+	_ = w.conn.SetWriteDeadline(time.Now().Add(w.timeout))
+	err := w.conn.WriteMessage(websocket.TextMessage, msg.data)
+	if err != nil {
+		msg.done <- err
+		_ = w.conn.Close()
+		return false
+	}
+
+	// counted before the sender is released, so stats read after send include the message
+	if w.onWrite != nil {
+		w.onWrite(len(msg.data))
+	}
+	msg.done <- nil
+	return true
+}
+
+// send queues data and waits until it is written. It blocks while the queue is full.
+func (w *writePump) send(ctx context.Context, data []byte) error {
+	return w.enqueue(ctx, w.queue, data)
+}
+
+// sendPriority writes data ahead of every queued message.
+func (w *writePump) sendPriority(ctx context.Context, data []byte) error {
+	return w.enqueue(ctx, w.priority, data)
+}
+
+func (w *writePump) enqueue(ctx context.Context, lane chan *outboundMessage, data []byte) error {
+	msg := &outboundMessage{ctx: ctx, data: data, done: make(chan error, 1)}
+
+	select {
+	case lane <- msg:
+	case <-w.stopped:
+		return errWriterStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-msg.done:
+		return err
+	case <-w.stopped:
+		// the last write may have completed just before the pump stopped
+		select {
+		case err := <-msg.done:
+			return err
+		default:
+			return errWriterStopped
+		}
+	}
+}
+
+// close stops the pump, queued messages fail with errWriterStopped. The socket is left to the caller.
+func (w *writePump) close() {
+	w.stopOnce.Do(func() { close(w.stop) })
+}
@@ -0,0 +1,143 @@
+package rlapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// dialTestSocket connects to a server running handler on the accepted socket.
+func dialTestSocket(t *testing.T, handler func(conn *websocket.Conn)) *websocket.Conn {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handler(conn)
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestWritePump_PriorityFirst(t *testing.T) {
+	received := make(chan string, 4)
+	conn := dialTestSocket(t, func(conn *websocket.Conn) {
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			received <- string(message)
+		}
+	})
+
+	// queue everything before the pump runs so the order is decided by the lanes alone
+	w := &writePump{
+		conn:     conn,
+		timeout:  time.Second,
+		priority: make(chan *outboundMessage, 1),
+		queue:    make(chan *outboundMessage, 3),
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	var messages []*outboundMessage
+	for _, data := range []string{"request 1", "request 2", "request 3"} {
+		msg := &outboundMessage{ctx: context.Background(), data: []byte(data), done: make(chan error, 1)}
+		w.queue <- msg
+		messages = append(messages, msg)
+	}
+	ping := &outboundMessage{ctx: context.Background(), data: []byte("ping"), done: make(chan error, 1)}
+	w.priority <- ping
+	messages = append(messages, ping)
+
+	go w.run()
+	defer w.close()
+
+	for _, msg := range messages {
+		if err := <-msg.done; err != nil {
+			t.Fatalf("write error = %v", err)
+		}
+	}
+	want := []string{"ping", "request 1", "request 2", "request 3"}
+	for _, data := range want {
+		if got := <-received; got != data {
+			t.Errorf("received %q, want %q", got, data)
+		}
+	}
+}
+
+func TestWritePump_WriteDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	// the server never reads, writes block once the socket buffers fill up
+	conn := dialTestSocket(t, func(conn *websocket.Conn) { <-release })
+
+	w := newWritePump(conn, 50*time.Millisecond, 4, nil)
+	defer w.close()
+
+	data := make([]byte, 1<<20)
+	var err error
+	for range 64 {
+		if err = w.send(context.Background(), data); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		t.Fatal("Expected a write to miss its deadline")
+	}
+
+	select {
+	case <-w.stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the pump to stop after a failed write")
+	}
+	if err := w.send(context.Background(), []byte("late")); !errors.Is(err, errWriterStopped) {
+		t.Errorf("send() after failure error = %v, want errWriterStopped", err)
+	}
+}
+
+func TestWritePump_CanceledWhileQueued(t *testing.T) {
+	conn := dialTestSocket(t, func(conn *websocket.Conn) {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+
+	w := &writePump{
+		conn:     conn,
+		timeout:  time.Second,
+		priority: make(chan *outboundMessage, 1),
+		queue:    make(chan *outboundMessage, 1),
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	msg := &outboundMessage{ctx: ctx, data: []byte("stale"), done: make(chan error, 1)}
+	w.queue <- msg
+	cancel()
+
+	go w.run()
+	defer w.close()
+
+	if err := <-msg.done; !errors.Is(err, context.Canceled) {
+		t.Errorf("write error = %v, want context.Canceled", err)
+	}
+}